@@ -0,0 +1,147 @@
+package collections
+
+import (
+	"errors"
+	"fmt"
+)
+
+// KeyCodecFromEncoder adapts a KeyEncoder into a KeyCodec,
+// turning the panics raised by the KeyEncoder into errors wrapping ErrEncoding.
+func KeyCodecFromEncoder[T any](kc KeyEncoder[T]) KeyCodec[T] {
+	if c, ok := kc.(codecKeyEncoder[T]); ok {
+		return c.kc
+	}
+	return keyEncoderCodec[T]{kc: kc}
+}
+
+// KeyEncoderFromCodec adapts a KeyCodec into a KeyEncoder, so that it can be
+// used by the collections. The errors returned by the KeyCodec are raised as
+// panics wrapping ErrEncoding, which are turned back into errors by the Safe
+// variants of the collections methods, ex: Map.SafeGet, Iterator.SafeKey.
+func KeyEncoderFromCodec[T any](kc KeyCodec[T]) KeyEncoder[T] {
+	if e, ok := kc.(keyEncoderCodec[T]); ok {
+		return e.kc
+	}
+	return codecKeyEncoder[T]{kc: kc}
+}
+
+// ValueCodecFromEncoder adapts a ValueEncoder into a ValueCodec,
+// turning the panics raised by the ValueEncoder into errors wrapping ErrEncoding.
+func ValueCodecFromEncoder[T any](vc ValueEncoder[T]) ValueCodec[T] {
+	if c, ok := vc.(codecValueEncoder[T]); ok {
+		return c.vc
+	}
+	return valueEncoderCodec[T]{vc: vc}
+}
+
+// ValueEncoderFromCodec adapts a ValueCodec into a ValueEncoder, so that it can be
+// used by the collections. The errors returned by the ValueCodec are raised as
+// panics wrapping ErrEncoding, which are turned back into errors by the Safe
+// variants of the collections methods, ex: Map.SafeGet, Iterator.SafeValue.
+func ValueEncoderFromCodec[T any](vc ValueCodec[T]) ValueEncoder[T] {
+	if e, ok := vc.(valueEncoderCodec[T]); ok {
+		return e.vc
+	}
+	return codecValueEncoder[T]{vc: vc}
+}
+
+type keyEncoderCodec[T any] struct {
+	kc KeyEncoder[T]
+}
+
+func (k keyEncoderCodec[T]) Stringify(key T) string { return k.kc.Stringify(key) }
+
+func (k keyEncoderCodec[T]) Encode(key T) (b []byte, err error) {
+	defer recoverEncoding(&err)
+	return k.kc.Encode(key), nil
+}
+
+func (k keyEncoderCodec[T]) Decode(b []byte) (read int, key T, err error) {
+	defer recoverEncoding(&err)
+	read, key = k.kc.Decode(b)
+	return read, key, nil
+}
+
+type codecKeyEncoder[T any] struct {
+	kc KeyCodec[T]
+}
+
+func (c codecKeyEncoder[T]) Stringify(key T) string { return c.kc.Stringify(key) }
+
+func (c codecKeyEncoder[T]) Encode(key T) []byte {
+	b, err := c.kc.Encode(key)
+	if err != nil {
+		panic(wrapEncodingError(err))
+	}
+	return b
+}
+
+func (c codecKeyEncoder[T]) Decode(b []byte) (int, T) {
+	read, key, err := c.kc.Decode(b)
+	if err != nil {
+		panic(wrapEncodingError(err))
+	}
+	return read, key
+}
+
+type valueEncoderCodec[T any] struct {
+	vc ValueEncoder[T]
+}
+
+func (v valueEncoderCodec[T]) Stringify(value T) string { return v.vc.Stringify(value) }
+func (v valueEncoderCodec[T]) Name() string             { return v.vc.Name() }
+
+func (v valueEncoderCodec[T]) Encode(value T) (b []byte, err error) {
+	defer recoverEncoding(&err)
+	return v.vc.Encode(value), nil
+}
+
+func (v valueEncoderCodec[T]) Decode(b []byte) (value T, err error) {
+	defer recoverEncoding(&err)
+	return v.vc.Decode(b), nil
+}
+
+type codecValueEncoder[T any] struct {
+	vc ValueCodec[T]
+}
+
+func (c codecValueEncoder[T]) Stringify(value T) string { return c.vc.Stringify(value) }
+func (c codecValueEncoder[T]) Name() string             { return c.vc.Name() }
+
+func (c codecValueEncoder[T]) Encode(value T) []byte {
+	b, err := c.vc.Encode(value)
+	if err != nil {
+		panic(wrapEncodingError(err))
+	}
+	return b
+}
+
+func (c codecValueEncoder[T]) Decode(b []byte) T {
+	v, err := c.vc.Decode(b)
+	if err != nil {
+		panic(wrapEncodingError(err))
+	}
+	return v
+}
+
+// recoverEncoding must be deferred, it recovers from the panics
+// raised by KeyEncoder and ValueEncoder implementations and
+// sets err to an error wrapping ErrEncoding.
+func recoverEncoding(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if rErr, ok := r.(error); ok {
+		*err = wrapEncodingError(rErr)
+		return
+	}
+	*err = fmt.Errorf("%w: %v", ErrEncoding, r)
+}
+
+func wrapEncodingError(err error) error {
+	if errors.Is(err, ErrEncoding) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrEncoding, err)
+}
@@ -0,0 +1,124 @@
+package collections
+
+import (
+	"errors"
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/stretchr/testify/require"
+)
+
+var errFailingCodec = errors.New("failing codec")
+
+// failingCodec is a ValueCodec which always fails, used for testing.
+type failingCodec struct{}
+
+func (failingCodec) Encode(string) ([]byte, error) { return nil, errFailingCodec }
+func (failingCodec) Decode([]byte) (string, error) { return "", errFailingCodec }
+func (failingCodec) Stringify(s string) string     { return s }
+func (failingCodec) Name() string                  { return "failing" }
+
+// failingKeyCodec is a KeyCodec which always fails, used for testing.
+type failingKeyCodec struct{}
+
+func (failingKeyCodec) Encode(string) ([]byte, error)      { return nil, errFailingCodec }
+func (failingKeyCodec) Decode([]byte) (int, string, error) { return 0, "", errFailingCodec }
+func (failingKeyCodec) Stringify(s string) string          { return s }
+
+func TestKeyCodecFromEncoder(t *testing.T) {
+	kc := KeyCodecFromEncoder(StringKeyEncoder)
+
+	b, err := kc.Encode("key")
+	require.NoError(t, err)
+	read, key, err := kc.Decode(b)
+	require.NoError(t, err)
+	require.Equal(t, len(b), read)
+	require.Equal(t, "key", key)
+
+	_, err = kc.Encode(string([]byte{0x1, 0x0}))
+	require.ErrorIs(t, err, ErrEncoding)
+	_, _, err = kc.Decode([]byte{0x1})
+	require.ErrorIs(t, err, ErrEncoding)
+
+	// round trip returns the original encoder
+	require.Equal(t, StringKeyEncoder, KeyEncoderFromCodec(kc))
+}
+
+func TestValueCodecFromEncoder(t *testing.T) {
+	vc := ValueCodecFromEncoder(DecValueEncoder)
+
+	b, err := vc.Encode(math.LegacyNewDec(10))
+	require.NoError(t, err)
+	v, err := vc.Decode(b)
+	require.NoError(t, err)
+	require.Equal(t, math.LegacyNewDec(10), v)
+	require.Equal(t, DecValueEncoder.Name(), vc.Name())
+
+	_, err = vc.Decode([]byte("invalid"))
+	require.ErrorIs(t, err, ErrEncoding)
+
+	require.Equal(t, DecValueEncoder, ValueEncoderFromCodec(vc))
+}
+
+func TestEncoderFromCodec(t *testing.T) {
+	kc := KeyEncoderFromCodec[string](failingKeyCodec{})
+	require.PanicsWithError(t, "collections: encoding error: failing codec", func() { kc.Encode("key") })
+
+	vc := ValueEncoderFromCodec[string](failingCodec{})
+	require.PanicsWithError(t, "collections: encoding error: failing codec", func() { vc.Decode([]byte{}) })
+
+	// the errors are not wrapped twice
+	_, err := ValueCodecFromEncoder[string](hiddenValueEncoder{vc}).Decode(nil)
+	require.ErrorIs(t, err, errFailingCodec)
+	require.Equal(t, "collections: encoding error: failing codec", err.Error())
+}
+
+// hiddenValueEncoder hides the ValueEncoder concrete type from the adapters unwrapping.
+type hiddenValueEncoder struct{ ValueEncoder[string] }
+
+func TestMapSafeGet(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewMap[string, math.LegacyDec](sk, 0, StringKeyEncoder, DecValueEncoder)
+
+	m.Insert(ctx, "valid", math.LegacyNewDec(1))
+	v, err := m.SafeGet(ctx, "valid")
+	require.NoError(t, err)
+	require.Equal(t, math.LegacyNewDec(1), v)
+
+	_, err = m.SafeGet(ctx, "missing")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// invalid key
+	_, err = m.SafeGet(ctx, string([]byte{0x1, 0x0}))
+	require.ErrorIs(t, err, ErrEncoding)
+
+	// corrupted value
+	m.GetStore(ctx).Set(StringKeyEncoder.Encode("corrupted"), []byte("invalid"))
+	require.Panics(t, func() { _, _ = m.Get(ctx, "corrupted") })
+	_, err = m.SafeGet(ctx, "corrupted")
+	require.ErrorIs(t, err, ErrEncoding)
+}
+
+func TestIteratorSafeKeyValue(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewMap[string, math.LegacyDec](sk, 0, StringKeyEncoder, DecValueEncoder)
+
+	m.Insert(ctx, "a", math.LegacyNewDec(1))
+	m.GetStore(ctx).Set([]byte("b"), []byte("invalid")) // not null terminated key
+
+	iter := m.Iterate(ctx, Range[string]{})
+	defer iter.Close()
+
+	k, err := iter.SafeKey()
+	require.NoError(t, err)
+	require.Equal(t, "a", k)
+	v, err := iter.SafeValue()
+	require.NoError(t, err)
+	require.Equal(t, math.LegacyNewDec(1), v)
+
+	iter.Next()
+	_, err = iter.SafeKey()
+	require.ErrorIs(t, err, ErrEncoding)
+	_, err = iter.SafeValue()
+	require.ErrorIs(t, err, ErrEncoding)
+}
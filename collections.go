@@ -4,8 +4,12 @@ import (
 	"errors"
 )
 
-// ErrNotFound is returned when an object is not found.
-var ErrNotFound = errors.New("collections: not found")
+var (
+	// ErrNotFound is returned when an object is not found.
+	ErrNotFound = errors.New("collections: not found")
+	// ErrEncoding is returned when a key or a value cannot be encoded or decoded.
+	ErrEncoding = errors.New("collections: encoding error")
+)
 
 // Namespace defines a storage namespace which must be unique in a single module
 // for all the different storage layer types: Map, Sequence, KeySet, Item, MultiIndex, IndexedMap
@@ -25,6 +29,33 @@ type KeyEncoder[T any] interface {
 	Stringify(key T) string
 }
 
+// copyingKeyDecoder is implemented by the built-in KeyEncoders. If decodeCopies
+// returns true, the decoded keys never retain the buffer, which iterators can then reuse.
+type copyingKeyDecoder interface {
+	decodeCopies() bool
+}
+
+// decodeCopies reports whether the keys decoded by the KeyEncoder never retain the buffer.
+func decodeCopies[K any](kc KeyEncoder[K]) bool {
+	c, ok := kc.(copyingKeyDecoder)
+	return ok && c.decodeCopies()
+}
+
+// SizedKeyEncoder is optionally implemented by KeyEncoder instances which can encode
+// keys into a provided buffer. Collections use it to encode keys with a single allocation.
+// It is implemented by all the built-in KeyEncoders except AccAddressKeyEncoder,
+// ValAddressKeyEncoder, ConsAddressKeyEncoder and SdkDecKeyEncoder, which are kept only
+// for state compatibility, and by composite and reversed KeyEncoders when all their parts
+// implement it. KeyEncoders adapted from upstream codecs implement it too.
+type SizedKeyEncoder[T any] interface {
+	KeyEncoder[T]
+	// Size returns the number of bytes needed to encode the key.
+	Size(key T) int
+	// PutKey encodes the key into the buffer, which must be at least
+	// Size(key) long, and returns the number of bytes written.
+	PutKey(buf []byte, key T) int
+}
+
 // ValueEncoder defines a generic interface which is implemented
 // by types that are capable of encoding and decoding collection values.
 type ValueEncoder[T any] interface {
@@ -37,3 +68,32 @@ type ValueEncoder[T any] interface {
 	// Name returns the name of the object.
 	Name() string
 }
+
+// KeyCodec defines a generic interface which is implemented by types that are
+// capable of encoding and decoding collections keys, reporting failures as
+// errors instead of panicking. KeyCodecFromEncoder and KeyEncoderFromCodec
+// convert between KeyCodec and KeyEncoder.
+type KeyCodec[T any] interface {
+	// Encode encodes the type T into bytes.
+	Encode(key T) ([]byte, error)
+	// Decode decodes the given bytes back into T.
+	// And it also must return the bytes of the buffer which were read.
+	Decode(b []byte) (int, T, error)
+	// Stringify returns a string representation of T.
+	Stringify(key T) string
+}
+
+// ValueCodec defines a generic interface which is implemented by types that are
+// capable of encoding and decoding collection values, reporting failures as
+// errors instead of panicking. ValueCodecFromEncoder and ValueEncoderFromCodec
+// convert between ValueCodec and ValueEncoder.
+type ValueCodec[T any] interface {
+	// Encode encodes the value T into bytes.
+	Encode(value T) ([]byte, error)
+	// Decode returns the type T given its bytes representation.
+	Decode(b []byte) (T, error)
+	// Stringify returns a string representation of T.
+	Stringify(value T) string
+	// Name returns the name of the object.
+	Name() string
+}
@@ -0,0 +1,255 @@
+package collections
+
+import (
+	"context"
+	"fmt"
+
+	storetypes "cosmossdk.io/store/types"
+)
+
+// Sub namespaces reserved by counted collections inside their namespace.
+const (
+	countedEntriesNamespace uint8 = 0
+	countedCountNamespace   uint8 = 1
+)
+
+// NewCountedMap instantiates a new CountedMap instance.
+func NewCountedMap[K, V any](
+	sk storetypes.StoreKey, namespace Namespace, kc KeyEncoder[K], vc ValueEncoder[V],
+) CountedMap[K, V] {
+	return NewCountedMapWithStore[K, V](StoreKeyAccessor(sk), namespace, kc, vc)
+}
+
+// NewCountedMapWithStore instantiates a new CountedMap instance which reads from
+// and writes to the KVStore provided by the StoreAccessor.
+func NewCountedMapWithStore[K, V any](
+	sa StoreAccessor, namespace Namespace, kc KeyEncoder[K], vc ValueEncoder[V],
+) CountedMap[K, V] {
+	m := NewMapWithStore[K, V](sa, namespace, kc, vc)
+	m.prefix = append(namespace.Prefix(), countedEntriesNamespace)
+	count := NewItemWithStore[uint64](sa, namespace, uint64Value{})
+	count.prefix = append(namespace.Prefix(), countedCountNamespace)
+	return CountedMap[K, V]{
+		m:     m,
+		count: count,
+	}
+}
+
+// CountedMap is a Map which keeps track of the number of its entries,
+// so that they can be counted without iterating over them.
+// The entries and the counter are saved in two sub namespaces
+// of the provided namespace, hence the state of a CountedMap
+// is not compatible with the state of a Map.
+type CountedMap[K, V any] struct {
+	m     Map[K, V]    // maintains the entries
+	count Item[uint64] // maintains the number of entries
+}
+
+// Len returns the number of entries in the map.
+func (c CountedMap[K, V]) Len(ctx context.Context) uint64 {
+	return c.count.GetOr(ctx, 0)
+}
+
+// Recount counts the entries of the map by iterating over them, then
+// saves and returns the result. It can be used to repair the counter,
+// or to initialize it in a store migration.
+func (c CountedMap[K, V]) Recount(ctx context.Context) uint64 {
+	iter := c.m.Iterate(ctx, Range[K]{})
+	defer iter.Close()
+
+	var n uint64
+	for ; iter.Valid(); iter.Next() {
+		n++
+	}
+	c.count.Set(ctx, n)
+	return n
+}
+
+// Has reports whether the key is present in the map.
+func (c CountedMap[K, V]) Has(ctx context.Context, k K) bool { return c.m.Has(ctx, k) }
+
+// Get returns the value associated with the key, or an error wrapping ErrNotFound.
+func (c CountedMap[K, V]) Get(ctx context.Context, k K) (V, error) { return c.m.Get(ctx, k) }
+
+// SafeGet works like Get, but instead of panicking when the key cannot be encoded
+// or the value cannot be decoded, it returns an error wrapping ErrEncoding.
+func (c CountedMap[K, V]) SafeGet(ctx context.Context, k K) (V, error) { return c.m.SafeGet(ctx, k) }
+
+// GetOr returns the value associated with the key, or the provided default.
+func (c CountedMap[K, V]) GetOr(ctx context.Context, k K, def V) V { return c.m.GetOr(ctx, k, def) }
+
+// GetMany returns the values associated with the provided keys, in the same order.
+func (c CountedMap[K, V]) GetMany(ctx context.Context, keys []K) (values []V, found []bool) {
+	return c.m.GetMany(ctx, keys)
+}
+
+// Iterate returns an iterator over the entries of the map within the provided range.
+func (c CountedMap[K, V]) Iterate(ctx context.Context, rng Ranger[K]) Iterator[K, V] {
+	return c.m.Iterate(ctx, rng)
+}
+
+// Insert inserts the key-value pair in the map,
+// the counter is increased only if the key is new.
+func (c CountedMap[K, V]) Insert(ctx context.Context, k K, v V) {
+	kBytes := encodeKey(c.m.kc, k)
+	store := c.m.GetStore(ctx)
+	if !store.Has(kBytes) {
+		c.add(ctx, 1)
+	}
+	store.Set(kBytes, c.m.vc.Encode(v))
+}
+
+// InsertMany inserts the provided key-value pairs in order,
+// the counter is increased only for the new keys.
+func (c CountedMap[K, V]) InsertMany(ctx context.Context, kvs []KeyValue[K, V]) {
+	store := c.m.GetStore(ctx)
+	var added uint64
+	for _, kv := range kvs {
+		kBytes := encodeKey(c.m.kc, kv.Key)
+		if !store.Has(kBytes) {
+			added++
+		}
+		store.Set(kBytes, c.m.vc.Encode(kv.Value))
+	}
+	c.add(ctx, added)
+}
+
+// Update applies the update function to the value associated with the key.
+// Returns an error if the key does not exist or if the update function fails.
+func (c CountedMap[K, V]) Update(ctx context.Context, k K, update func(V) (V, error)) error {
+	return c.m.Update(ctx, k, update)
+}
+
+// Upsert applies the upsert function to the value associated with the key,
+// the counter is increased only if the key is new.
+func (c CountedMap[K, V]) Upsert(ctx context.Context, k K, upsert func(old V, found bool) V) {
+	var existed bool
+	c.m.Upsert(ctx, k, func(old V, found bool) V {
+		existed = found
+		return upsert(old, found)
+	})
+	// the counter is increased once the value is stored,
+	// so a panicking upsert function leaves it untouched.
+	if !existed {
+		c.add(ctx, 1)
+	}
+}
+
+// Delete removes the key-value pair associated with the key from the map
+// and decreases the counter. Returns an error if the key does not exist.
+func (c CountedMap[K, V]) Delete(ctx context.Context, k K) error {
+	if err := c.m.Delete(ctx, k); err != nil {
+		return err
+	}
+	c.sub(ctx, 1)
+	return nil
+}
+
+// DeleteMany removes the provided keys from the map and decreases the counter
+// by the number of deleted entries. errs[i] wraps ErrNotFound if keys[i] did not exist.
+func (c CountedMap[K, V]) DeleteMany(ctx context.Context, keys []K) (errs []error) {
+	errs = c.m.DeleteMany(ctx, keys)
+	var deleted uint64
+	for _, err := range errs {
+		if err == nil {
+			deleted++
+		}
+	}
+	c.sub(ctx, deleted)
+	return errs
+}
+
+// Clear removes all the entries within the provided range, a nil Ranger clears the
+// whole map, then decreases the counter. It returns the number of deleted entries.
+func (c CountedMap[K, V]) Clear(ctx context.Context, rng Ranger[K]) int {
+	deleted := c.m.Clear(ctx, rng)
+	c.sub(ctx, uint64(deleted))
+	return deleted
+}
+
+func (c CountedMap[K, V]) add(ctx context.Context, n uint64) {
+	if n == 0 {
+		return
+	}
+	c.count.Set(ctx, c.Len(ctx)+n)
+}
+
+func (c CountedMap[K, V]) sub(ctx context.Context, n uint64) {
+	if n == 0 {
+		return
+	}
+	count := c.Len(ctx)
+	if n > count {
+		panic(fmt.Errorf("collections: counter underflow, counted %d entries but deleted %d, use Recount to repair it", count, n))
+	}
+	c.count.Set(ctx, count-n)
+}
+
+// NewCountedKeySet instantiates a new CountedKeySet instance.
+func NewCountedKeySet[K any](sk storetypes.StoreKey, namespace Namespace, keyEncoder KeyEncoder[K]) CountedKeySet[K] {
+	return NewCountedKeySetWithStore[K](StoreKeyAccessor(sk), namespace, keyEncoder)
+}
+
+// NewCountedKeySetWithStore instantiates a new CountedKeySet instance which reads
+// from and writes to the KVStore provided by the StoreAccessor.
+func NewCountedKeySetWithStore[K any](sa StoreAccessor, namespace Namespace, keyEncoder KeyEncoder[K]) CountedKeySet[K] {
+	return (CountedKeySet[K])(NewCountedMapWithStore[K, setObject](sa, namespace, keyEncoder, setObject{}))
+}
+
+// CountedKeySet is a KeySet which keeps track of the number of its keys,
+// so that they can be counted without iterating over them.
+// Like CountedMap, its state is not compatible with the state of a KeySet.
+type CountedKeySet[K any] CountedMap[K, setObject]
+
+// Len returns the number of keys in the set.
+func (s CountedKeySet[K]) Len(ctx context.Context) uint64 {
+	return (CountedMap[K, setObject])(s).Len(ctx)
+}
+
+// Recount counts the keys of the set by iterating over them,
+// then saves and returns the result.
+func (s CountedKeySet[K]) Recount(ctx context.Context) uint64 {
+	return (CountedMap[K, setObject])(s).Recount(ctx)
+}
+
+// Has reports whether the key K is present or not in the set.
+func (s CountedKeySet[K]) Has(ctx context.Context, k K) bool {
+	return (CountedMap[K, setObject])(s).Has(ctx, k)
+}
+
+// Insert inserts the key K in the set.
+func (s CountedKeySet[K]) Insert(ctx context.Context, k K) {
+	(CountedMap[K, setObject])(s).Insert(ctx, k, setObject{})
+}
+
+// InsertMany inserts the provided keys in the set.
+func (s CountedKeySet[K]) InsertMany(ctx context.Context, keys []K) {
+	kvs := make([]KeyValue[K, setObject], len(keys))
+	for i, k := range keys {
+		kvs[i] = KeyValue[K, setObject]{Key: k}
+	}
+	(CountedMap[K, setObject])(s).InsertMany(ctx, kvs)
+}
+
+// Delete deletes the key from the set.
+// Does not check if the key exists or not.
+func (s CountedKeySet[K]) Delete(ctx context.Context, k K) {
+	_ = (CountedMap[K, setObject])(s).Delete(ctx, k)
+}
+
+// DeleteMany deletes the provided keys from the set.
+// Does not check if the keys exist or not.
+func (s CountedKeySet[K]) DeleteMany(ctx context.Context, keys []K) {
+	_ = (CountedMap[K, setObject])(s).DeleteMany(ctx, keys)
+}
+
+// Clear deletes all the keys within the provided range from the set,
+// a nil Ranger clears the whole set. It returns the number of deleted keys.
+func (s CountedKeySet[K]) Clear(ctx context.Context, r Ranger[K]) int {
+	return (CountedMap[K, setObject])(s).Clear(ctx, r)
+}
+
+// Iterate returns a KeySetIterator over the provided range of keys.
+func (s CountedKeySet[K]) Iterate(ctx context.Context, r Ranger[K]) KeySetIterator[K] {
+	return (KeySetIterator[K])((CountedMap[K, setObject])(s).Iterate(ctx, r))
+}
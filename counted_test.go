@@ -0,0 +1,86 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountedMap(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewCountedMap[string, uint64](sk, 0, StringKeyEncoder, uint64Value{})
+	require.Equal(t, uint64(0), m.Len(ctx))
+
+	m.Insert(ctx, "a", 1)
+	m.Insert(ctx, "b", 2)
+	// overwriting does not change the count
+	m.Insert(ctx, "a", 3)
+	require.Equal(t, uint64(2), m.Len(ctx))
+
+	m.Upsert(ctx, "c", func(old uint64, _ bool) uint64 { return old + 1 })
+	m.Upsert(ctx, "c", func(old uint64, _ bool) uint64 { return old + 1 })
+	require.Equal(t, uint64(3), m.Len(ctx))
+	// a recovered panic of the upsert function does not change the count
+	require.Panics(t, func() {
+		m.Upsert(ctx, "e", func(uint64, bool) uint64 { panic("upsert failed") })
+	})
+	require.False(t, m.Has(ctx, "e"))
+	require.Equal(t, uint64(3), m.Len(ctx))
+	require.NoError(t, m.Update(ctx, "c", func(v uint64) (uint64, error) { return v * 10, nil }))
+	require.Equal(t, uint64(20), m.GetOr(ctx, "c", 0))
+	require.Equal(t, uint64(3), m.Len(ctx))
+
+	m.InsertMany(ctx, []KeyValue[string, uint64]{{Key: "c", Value: 0}, {Key: "d", Value: 0}, {Key: "d", Value: 1}})
+	require.Equal(t, uint64(4), m.Len(ctx))
+
+	require.NoError(t, m.Delete(ctx, "a"))
+	require.ErrorIs(t, m.Delete(ctx, "a"), ErrNotFound)
+	require.Equal(t, uint64(3), m.Len(ctx))
+
+	errs := m.DeleteMany(ctx, []string{"b", "missing"})
+	require.NoError(t, errs[0])
+	require.ErrorIs(t, errs[1], ErrNotFound)
+	require.Equal(t, uint64(2), m.Len(ctx))
+
+	require.Equal(t, []string{"c", "d"}, m.Iterate(ctx, Range[string]{}).Keys())
+	require.Equal(t, 1, m.Clear(ctx, Range[string]{}.StartInclusive("d")))
+	require.Equal(t, uint64(1), m.Len(ctx))
+	require.Equal(t, 1, m.Clear(ctx, nil))
+	require.Equal(t, uint64(0), m.Len(ctx))
+}
+
+func TestCountedMapRecount(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewCountedMap[string, uint64](sk, 0, StringKeyEncoder, uint64Value{})
+	m.InsertMany(ctx, []KeyValue[string, uint64]{{Key: "a", Value: 1}, {Key: "b", Value: 2}})
+
+	// corrupt the counter
+	m.count.Set(ctx, 10)
+	require.Equal(t, uint64(2), m.Recount(ctx))
+	require.Equal(t, uint64(2), m.Len(ctx))
+
+	m.count.Set(ctx, 0)
+	require.Panics(t, func() { _ = m.Delete(ctx, "a") })
+}
+
+func TestCountedKeySet(t *testing.T) {
+	sk, ctx, _ := deps()
+	s := NewCountedKeySet[uint64](sk, 0, Uint64KeyEncoder)
+
+	s.Insert(ctx, 1)
+	s.Insert(ctx, 1)
+	s.InsertMany(ctx, []uint64{2, 3, 4})
+	require.Equal(t, uint64(4), s.Len(ctx))
+	require.True(t, s.Has(ctx, 1))
+
+	// deleting missing keys is a no-op
+	s.Delete(ctx, 10)
+	s.Delete(ctx, 1)
+	s.DeleteMany(ctx, []uint64{2, 10})
+	require.Equal(t, uint64(2), s.Len(ctx))
+	require.Equal(t, []uint64{3, 4}, s.Iterate(ctx, Range[uint64]{}).Keys())
+
+	require.Equal(t, uint64(2), s.Recount(ctx))
+	require.Equal(t, 2, s.Clear(ctx, nil))
+	require.Equal(t, uint64(0), s.Len(ctx))
+}
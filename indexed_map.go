@@ -1,8 +1,10 @@
 package collections
 
 import (
+	"context"
+	"fmt"
+
 	storetypes "cosmossdk.io/store/types"
-	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // IndexersProvider is implemented by structs containing
@@ -22,11 +24,23 @@ type Indexer[PK any, V any] interface {
 	// an object into its state, so the Indexer here
 	// creates the relationship between primary key
 	// and the fields of the object V.
-	Insert(ctx sdk.Context, primaryKey PK, v V)
+	Insert(ctx context.Context, primaryKey PK, v V)
 	// Delete is called when the IndexedMap is removing
 	// the object V and hence the relationship between
 	// V and its primary keys need to be removed too.
-	Delete(ctx sdk.Context, primaryKey PK, v V)
+	Delete(ctx context.Context, primaryKey PK, v V)
+}
+
+// BatchIndexer can be optionally implemented by an Indexer
+// to apply many relationship changes at once, it is used
+// by the IndexedMap batch operations.
+type BatchIndexer[PK any, V any] interface {
+	// InsertMany creates the relationships between every
+	// primaryKeys[i] and the object vs[i].
+	InsertMany(ctx context.Context, primaryKeys []PK, vs []V)
+	// DeleteMany removes the relationships between every
+	// primaryKeys[i] and the object vs[i].
+	DeleteMany(ctx context.Context, primaryKeys []PK, vs []V)
 }
 
 // NewIndexedMap instantiates a new IndexedMap instance.
@@ -36,7 +50,18 @@ func NewIndexedMap[PK any, V any, I IndexersProvider[PK, V]](
 	valueEncoder ValueEncoder[V],
 	indexers I,
 ) IndexedMap[PK, V, I] {
-	m := NewMap[PK, V](storeKey, namespace, primaryKeyEncoder, valueEncoder)
+	return NewIndexedMapWithStore[PK, V, I](StoreKeyAccessor(storeKey), namespace, primaryKeyEncoder, valueEncoder, indexers)
+}
+
+// NewIndexedMapWithStore instantiates a new IndexedMap instance which reads from
+// and writes to the KVStore provided by the StoreAccessor.
+func NewIndexedMapWithStore[PK any, V any, I IndexersProvider[PK, V]](
+	sa StoreAccessor, namespace Namespace,
+	primaryKeyEncoder KeyEncoder[PK],
+	valueEncoder ValueEncoder[V],
+	indexers I,
+) IndexedMap[PK, V, I] {
+	m := NewMapWithStore[PK, V](sa, namespace, primaryKeyEncoder, valueEncoder)
 	return IndexedMap[PK, V, I]{
 		m:       m,
 		Indexes: indexers,
@@ -51,20 +76,31 @@ type IndexedMap[PK any, V any, I IndexersProvider[PK, V]] struct {
 }
 
 // Get returns the object V given its primary key PK.
-func (i IndexedMap[PK, V, I]) Get(ctx sdk.Context, key PK) (V, error) {
+func (i IndexedMap[PK, V, I]) Get(ctx context.Context, key PK) (V, error) {
 	return i.m.Get(ctx, key)
 }
 
+// SafeGet works like Get, but instead of panicking when the primary key cannot
+// be encoded or the object cannot be decoded, it returns an error wrapping ErrEncoding.
+func (i IndexedMap[PK, V, I]) SafeGet(ctx context.Context, key PK) (V, error) {
+	return i.m.SafeGet(ctx, key)
+}
+
+// Has reports whether an object with the given primary key PK exists.
+func (i IndexedMap[PK, V, I]) Has(ctx context.Context, key PK) bool {
+	return i.m.Has(ctx, key)
+}
+
 // GetOr returns the object V given its primary key PK, or if the operation fails
 // returns the provided default.
-func (i IndexedMap[PK, V, I]) GetOr(ctx sdk.Context, key PK, def V) V {
+func (i IndexedMap[PK, V, I]) GetOr(ctx context.Context, key PK, def V) V {
 	return i.m.GetOr(ctx, key, def)
 }
 
 // Insert inserts the object v into the Map using the primary key, then
 // iterates over every registered Indexer and instructs them to create
 // the relationship between the primary key PK and the object v.
-func (i IndexedMap[PK, V, I]) Insert(ctx sdk.Context, key PK, v V) {
+func (i IndexedMap[PK, V, I]) Insert(ctx context.Context, key PK, v V) {
 	// before inserting we need to assert if another instance of this
 	// primary key exist in order to remove old relationships from indexes.
 	old, err := i.m.Get(ctx, key)
@@ -79,7 +115,7 @@ func (i IndexedMap[PK, V, I]) Insert(ctx sdk.Context, key PK, v V) {
 // Delete fetches the object from the Map removes it from the Map
 // then instructs every Indexer to remove the relationships between
 // the object and the associated primary keys.
-func (i IndexedMap[PK, V, I]) Delete(ctx sdk.Context, key PK) error {
+func (i IndexedMap[PK, V, I]) Delete(ctx context.Context, key PK) error {
 	// we prefetch the object
 	v, err := i.m.Get(ctx, key)
 	if err != nil {
@@ -94,15 +130,115 @@ func (i IndexedMap[PK, V, I]) Delete(ctx sdk.Context, key PK) error {
 	return nil
 }
 
+// GetMany returns the objects associated with the provided primary keys, in the same order.
+// found[i] reports whether keys[i] exists, if it does not values[i] is the zero value of V.
+func (i IndexedMap[PK, V, I]) GetMany(ctx context.Context, keys []PK) (values []V, found []bool) {
+	return i.m.GetMany(ctx, keys)
+}
+
+// InsertMany inserts the provided objects, replacing the existing ones,
+// then updates every registered Indexer once for the whole batch.
+// If a primary key appears more than once the last object wins.
+func (i IndexedMap[PK, V, I]) InsertMany(ctx context.Context, kvs []KeyValue[PK, V]) {
+	store := i.m.GetStore(ctx)
+	// deduplicate primary keys, so that the indexes only
+	// see the final object associated with each of them.
+	positions := make(map[string]int, len(kvs))
+	keys := make([][]byte, 0, len(kvs))
+	pks := make([]PK, 0, len(kvs))
+	vs := make([]V, 0, len(kvs))
+	for _, kv := range kvs {
+		kBytes := encodeKey(i.m.kc, kv.Key)
+		if pos, ok := positions[string(kBytes)]; ok {
+			vs[pos] = kv.Value
+			continue
+		}
+		positions[string(kBytes)] = len(keys)
+		keys = append(keys, kBytes)
+		pks = append(pks, kv.Key)
+		vs = append(vs, kv.Value)
+	}
+	// before inserting we need to remove the relationships of the objects being replaced.
+	var (
+		oldPks []PK
+		oldVs  []V
+	)
+	for index, kBytes := range keys {
+		vBytes := store.Get(kBytes)
+		if vBytes == nil {
+			continue
+		}
+		oldPks = append(oldPks, pks[index])
+		oldVs = append(oldVs, i.m.vc.Decode(vBytes))
+	}
+	i.unindexMany(ctx, oldPks, oldVs)
+	// insert and index
+	for index, kBytes := range keys {
+		store.Set(kBytes, i.m.vc.Encode(vs[index]))
+	}
+	i.indexMany(ctx, pks, vs)
+}
+
+// DeleteMany removes the objects associated with the provided primary keys,
+// then updates every registered Indexer once for the whole batch.
+// It returns the per key result, errs[i] wraps ErrNotFound if keys[i] did not exist.
+func (i IndexedMap[PK, V, I]) DeleteMany(ctx context.Context, keys []PK) (errs []error) {
+	store := i.m.GetStore(ctx)
+	errs = make([]error, len(keys))
+	var (
+		pks []PK
+		vs  []V
+	)
+	for index, key := range keys {
+		kBytes := encodeKey(i.m.kc, key)
+		vBytes := store.Get(kBytes)
+		if vBytes == nil {
+			errs[index] = fmt.Errorf("%w: '%s' with key %s", ErrNotFound, i.m.typeName, i.m.kc.Stringify(key))
+			continue
+		}
+		store.Delete(kBytes)
+		pks = append(pks, key)
+		vs = append(vs, i.m.vc.Decode(vBytes))
+	}
+	i.unindexMany(ctx, pks, vs)
+	return errs
+}
+
+// Clear removes all the objects whose primary keys are within the provided range,
+// a nil Ranger clears the whole map, then instructs every Indexer to remove the
+// relationships of the deleted objects. It returns the number of deleted objects.
+func (i IndexedMap[PK, V, I]) Clear(ctx context.Context, rng Ranger[PK]) int {
+	if rng == nil {
+		rng = Range[PK]{}
+	}
+	kvs := i.m.Iterate(ctx, rng).KeyValues()
+	pks := make([]PK, len(kvs))
+	vs := make([]V, len(kvs))
+	store := i.m.GetStore(ctx)
+	for index, kv := range kvs {
+		store.Delete(encodeKey(i.m.kc, kv.Key))
+		pks[index] = kv.Key
+		vs[index] = kv.Value
+	}
+	i.unindexMany(ctx, pks, vs)
+	return len(kvs)
+}
+
 // Iterate iterates over the underlying store containing the concrete objects.
 // The range provided filters over the primary keys.
-func (i IndexedMap[PK, V, I]) Iterate(ctx sdk.Context, rng Ranger[PK]) Iterator[PK, V] {
+func (i IndexedMap[PK, V, I]) Iterate(ctx context.Context, rng Ranger[PK]) Iterator[PK, V] {
 	return i.m.Iterate(ctx, rng)
 }
 
+// IterateKeys iterates over the primary keys within the provided range,
+// the objects are never decoded.
+func (i IndexedMap[PK, V, I]) IterateKeys(ctx context.Context, rng Ranger[PK]) KeySetIterator[PK] {
+	return i.m.IterateKeys(ctx, rng)
+}
+
 // Collect collects all the object from the provided IndexerIterator.
 // Panics if PK records given by the iter are not in the store.
-func (i IndexedMap[PK, V, I]) Collect(ctx sdk.Context, iter interface{ PrimaryKeys() []PK }) []V {
+func (i IndexedMap[PK, V, I]) Collect(ctx context.Context, iter interface{ PrimaryKeys() []PK }) []V {
 	pks := iter.PrimaryKeys()
 	vs := make([]V, len(pks))
 	for index, pk := range pks {
@@ -115,13 +251,43 @@ func (i IndexedMap[PK, V, I]) Collect(ctx sdk.Context, iter interface{ PrimaryKe
 	return vs
 }
 
-func (i IndexedMap[PK, V, I]) index(ctx sdk.Context, key PK, v V) {
+func (i IndexedMap[PK, V, I]) index(ctx context.Context, key PK, v V) {
 	for _, indexer := range i.Indexes.IndexerList() {
 		indexer.Insert(ctx, key, v)
 	}
 }
 
-func (i IndexedMap[PK, V, I]) unindex(ctx sdk.Context, key PK, v V) {
+func (i IndexedMap[PK, V, I]) indexMany(ctx context.Context, keys []PK, vs []V) {
+	if len(keys) == 0 {
+		return
+	}
+	for _, indexer := range i.Indexes.IndexerList() {
+		if batch, ok := indexer.(BatchIndexer[PK, V]); ok {
+			batch.InsertMany(ctx, keys, vs)
+			continue
+		}
+		for index, key := range keys {
+			indexer.Insert(ctx, key, vs[index])
+		}
+	}
+}
+
+func (i IndexedMap[PK, V, I]) unindexMany(ctx context.Context, keys []PK, vs []V) {
+	if len(keys) == 0 {
+		return
+	}
+	for _, indexer := range i.Indexes.IndexerList() {
+		if batch, ok := indexer.(BatchIndexer[PK, V]); ok {
+			batch.DeleteMany(ctx, keys, vs)
+			continue
+		}
+		for index, key := range keys {
+			indexer.Delete(ctx, key, vs[index])
+		}
+	}
+}
+
+func (i IndexedMap[PK, V, I]) unindex(ctx context.Context, key PK, v V) {
 	for _, indexer := range i.Indexes.IndexerList() {
 		indexer.Delete(ctx, key, v)
 	}
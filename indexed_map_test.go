@@ -71,3 +71,93 @@ func TestIndexedMap(t *testing.T) {
 	persons := m.Iterate(ctx, Range[uint64]{}).Values()
 	require.Equal(t, []person{{1, "new york"}, {2, "new york"}}, persons)
 }
+
+// sequentialIndex hides the BatchIndexer implementation of MultiIndex.
+type sequentialIndex struct {
+	Indexer[uint64, person]
+}
+
+type mixedIndexes struct {
+	City       MultiIndex[string, uint64, person]
+	Sequential sequentialIndex
+}
+
+func (i mixedIndexes) IndexerList() []Indexer[uint64, person] {
+	return []Indexer[uint64, person]{i.City, i.Sequential}
+}
+
+func TestIndexedMapBatch(t *testing.T) {
+	sk, ctx, _ := deps()
+	cityIndex := func(namespace Namespace) MultiIndex[string, uint64, person] {
+		return NewMultiIndex[string, uint64, person](sk, namespace,
+			StringKeyEncoder, Uint64KeyEncoder,
+			func(v person) string {
+				return v.City
+			})
+	}
+	m := NewIndexedMap[uint64, person, mixedIndexes](
+		sk, 0,
+		Uint64KeyEncoder, jsonValue[person]{},
+		mixedIndexes{
+			City:       cityIndex(1),
+			Sequential: sequentialIndex{cityIndex(2)},
+		},
+	)
+	sequential := m.Indexes.Sequential.Indexer.(MultiIndex[string, uint64, person])
+
+	m.Insert(ctx, 0, person{ID: 0, City: "milan"})
+	m.InsertMany(ctx, []KeyValue[uint64, person]{
+		{Key: 0, Value: person{ID: 0, City: "rome"}},
+		{Key: 1, Value: person{ID: 1, City: "milan"}},
+		{Key: 2, Value: person{ID: 2, City: "paris"}},
+		// duplicated primary key, last one wins
+		{Key: 2, Value: person{ID: 2, City: "milan"}},
+	})
+
+	for _, index := range []MultiIndex[string, uint64, person]{m.Indexes.City, sequential} {
+		require.Equal(t, []uint64{1, 2}, index.ExactMatch(ctx, "milan").PrimaryKeys())
+		require.Equal(t, []uint64{0}, index.ExactMatch(ctx, "rome").PrimaryKeys())
+		require.Empty(t, index.ExactMatch(ctx, "paris").PrimaryKeys())
+	}
+
+	values, found := m.GetMany(ctx, []uint64{2, 3, 0})
+	require.Equal(t, []bool{true, false, true}, found)
+	require.Equal(t, []person{{2, "milan"}, {}, {0, "rome"}}, values)
+
+	errs := m.DeleteMany(ctx, []uint64{1, 3, 1})
+	require.NoError(t, errs[0])
+	require.ErrorIs(t, errs[1], ErrNotFound)
+	require.ErrorIs(t, errs[2], ErrNotFound)
+	require.False(t, m.Has(ctx, 1))
+
+	for _, index := range []MultiIndex[string, uint64, person]{m.Indexes.City, sequential} {
+		require.Equal(t, []uint64{2}, index.ExactMatch(ctx, "milan").PrimaryKeys())
+	}
+}
+
+func TestIndexedMapClear(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewIndexedMap[uint64, person, indexes](
+		sk, 0,
+		Uint64KeyEncoder, jsonValue[person]{},
+		indexes{
+			City: NewMultiIndex[string, uint64, person](sk, 1,
+				StringKeyEncoder, Uint64KeyEncoder,
+				func(v person) string {
+					return v.City
+				}),
+		},
+	)
+
+	m.Insert(ctx, 0, person{ID: 0, City: "milan"})
+	m.Insert(ctx, 1, person{ID: 1, City: "new york"})
+	m.Insert(ctx, 2, person{ID: 2, City: "milan"})
+
+	require.Equal(t, 2, m.Clear(ctx, Range[uint64]{}.StartInclusive(1)))
+	require.Equal(t, []uint64{0}, m.Indexes.City.ExactMatch(ctx, "milan").PrimaryKeys())
+	require.Empty(t, m.Indexes.City.ExactMatch(ctx, "new york").PrimaryKeys())
+
+	require.Equal(t, 1, m.Clear(ctx, nil))
+	require.Empty(t, m.Iterate(ctx, Range[uint64]{}).Keys())
+	require.Empty(t, m.Indexes.City.Iterate(ctx, Range[Pair[string, uint64]]{}).FullKeys())
+}
@@ -1,8 +1,9 @@
 package collections
 
 import (
+	"context"
+
 	storetypes "cosmossdk.io/store/types"
-	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // IndexerIterator wraps a KeySetIterator to provide more useful functionalities
@@ -44,7 +45,17 @@ func NewMultiIndex[IK, PK any, V any](
 	indexKeyEncoder KeyEncoder[IK], primaryKeyEncoder KeyEncoder[PK],
 	getIndexingKeyFunc func(v V) IK,
 ) MultiIndex[IK, PK, V] {
-	ks := NewKeySet[Pair[IK, PK]](sk, namespace, PairKeyEncoder[IK, PK](indexKeyEncoder, primaryKeyEncoder))
+	return NewMultiIndexWithStore[IK, PK, V](StoreKeyAccessor(sk), namespace, indexKeyEncoder, primaryKeyEncoder, getIndexingKeyFunc)
+}
+
+// NewMultiIndexWithStore instantiates a new MultiIndex instance which reads from
+// and writes to the KVStore provided by the StoreAccessor.
+func NewMultiIndexWithStore[IK, PK any, V any](
+	sa StoreAccessor, namespace Namespace,
+	indexKeyEncoder KeyEncoder[IK], primaryKeyEncoder KeyEncoder[PK],
+	getIndexingKeyFunc func(v V) IK,
+) MultiIndex[IK, PK, V] {
+	ks := NewKeySetWithStore[Pair[IK, PK]](sa, namespace, PairKeyEncoder[IK, PK](indexKeyEncoder, primaryKeyEncoder))
 	return MultiIndex[IK, PK, V]{
 		jointKeys:      ks,
 		getIndexingKey: getIndexingKeyFunc,
@@ -72,30 +83,48 @@ type MultiIndex[IK, PK, V any] struct {
 }
 
 // Insert implements the Indexer interface.
-func (i MultiIndex[IK, PK, V]) Insert(ctx sdk.Context, pk PK, v V) {
+func (i MultiIndex[IK, PK, V]) Insert(ctx context.Context, pk PK, v V) {
 	indexingKey := i.getIndexingKey(v)
 	i.jointKeys.Insert(ctx, Join(indexingKey, pk))
 }
 
 // Delete implements the Indexer interface.
-func (i MultiIndex[IK, PK, V]) Delete(ctx sdk.Context, pk PK, v V) {
+func (i MultiIndex[IK, PK, V]) Delete(ctx context.Context, pk PK, v V) {
 	indexingKey := i.getIndexingKey(v)
 	i.jointKeys.Delete(ctx, Join(indexingKey, pk))
 }
 
+// InsertMany implements the BatchIndexer interface.
+func (i MultiIndex[IK, PK, V]) InsertMany(ctx context.Context, pks []PK, vs []V) {
+	i.jointKeys.InsertMany(ctx, i.jointKeysOf(pks, vs))
+}
+
+// DeleteMany implements the BatchIndexer interface.
+func (i MultiIndex[IK, PK, V]) DeleteMany(ctx context.Context, pks []PK, vs []V) {
+	i.jointKeys.DeleteMany(ctx, i.jointKeysOf(pks, vs))
+}
+
+func (i MultiIndex[IK, PK, V]) jointKeysOf(pks []PK, vs []V) []Pair[IK, PK] {
+	keys := make([]Pair[IK, PK], len(pks))
+	for index, pk := range pks {
+		keys[index] = Join(i.getIndexingKey(vs[index]), pk)
+	}
+	return keys
+}
+
 // Iterate iterates over the provided range.
-func (i MultiIndex[IK, PK, V]) Iterate(ctx sdk.Context, rng Ranger[Pair[IK, PK]]) IndexerIterator[IK, PK] {
+func (i MultiIndex[IK, PK, V]) Iterate(ctx context.Context, rng Ranger[Pair[IK, PK]]) IndexerIterator[IK, PK] {
 	iter := i.jointKeys.Iterate(ctx, rng)
 	return (IndexerIterator[IK, PK])(iter)
 }
 
 // ExactMatch returns an iterator of all the primary keys of objects which contain
 // the provided indexing key ik.
-func (i MultiIndex[IK, PK, V]) ExactMatch(ctx sdk.Context, ik IK) IndexerIterator[IK, PK] {
+func (i MultiIndex[IK, PK, V]) ExactMatch(ctx context.Context, ik IK) IndexerIterator[IK, PK] {
 	return i.Iterate(ctx, PairRange[IK, PK]{}.Prefix(ik))
 }
 
 // ReverseExactMatch works in the same way as ExactMatch, but the iteration happens in reverse.
-func (i MultiIndex[IK, PK, V]) ReverseExactMatch(ctx sdk.Context, ik IK) IndexerIterator[IK, PK] {
+func (i MultiIndex[IK, PK, V]) ReverseExactMatch(ctx context.Context, ik IK) IndexerIterator[IK, PK] {
 	return i.Iterate(ctx, PairRange[IK, PK]{}.Prefix(ik).Descending())
 }
@@ -0,0 +1,259 @@
+package collections
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdkcollections "cosmossdk.io/collections"
+	collcodec "cosmossdk.io/collections/codec"
+)
+
+// This file bridges the collections of this package with the upstream
+// cosmossdk.io/collections ones, so that modules can be migrated one
+// collection at a time without rewriting state.
+//
+// Both libraries store an object at the namespace prefix followed by the
+// encoded key, so a collection of this package and an upstream collection
+// created with UpstreamPrefix(namespace) and the same key encoding share state:
+//
+//	sdkcollections.NewMap(schemaBuilder, collections.UpstreamPrefix(1), "balances",
+//		collections.ToUpstreamKeyCodec(collections.AccAddressBytesKeyEncoder),
+//		collections.ToUpstreamValueCodec(collections.IntValueEncoder),
+//	)
+//
+// BoolKeyEncoder and the unsigned and signed integer KeyEncoders are byte compatible
+// with the upstream codecs in any position of the key.
+//
+// StringKeyEncoder, BytesKeyEncoder, AccAddressBytesKeyEncoder, ValAddressBytesKeyEncoder
+// and ConsAddressBytesKeyEncoder match only the upstream non terminal encodings, so they
+// are compatible only as the non terminal parts of a composite key. Upstream encodes
+// the only key of a collection, or the last part of a composite key, using the terminal
+// encoding, which has neither a terminator nor a length prefix. For those keys use
+// TerminalStringKeyEncoder, TerminalBytesKeyEncoder, or the upstream codec adapted
+// with FromUpstreamKeyCodec, ex: FromUpstreamKeyCodec(sdk.AccAddressKey).
+
+// UpstreamPrefix returns the cosmossdk.io/collections Prefix
+// which maps to the same storage namespace.
+func UpstreamPrefix(namespace Namespace) sdkcollections.Prefix {
+	return sdkcollections.NewPrefix(int(namespace))
+}
+
+// FromUpstreamKeyCodec adapts a cosmossdk.io/collections KeyCodec into a KeyEncoder
+// which uses the terminal encoding of the KeyCodec. It must be used for keys which are
+// either the only key of the collection or the last part of a composite key.
+// Use FromUpstreamNonTerminalKeyCodec for the other parts of composite keys.
+func FromUpstreamKeyCodec[K any](kc collcodec.KeyCodec[K]) KeyEncoder[K] {
+	return upstreamKeyEncoder[K]{kc: kc}
+}
+
+// FromUpstreamNonTerminalKeyCodec adapts a cosmossdk.io/collections KeyCodec into
+// a KeyEncoder which uses the non terminal encoding of the KeyCodec. It must be used
+// for the parts of composite keys which are not the last one, ex:
+//
+//	PairKeyEncoder(
+//		FromUpstreamNonTerminalKeyCodec(sdk.AccAddressKey),
+//		FromUpstreamKeyCodec(collcodec.NewStringKeyCodec[string]()),
+//	)
+//
+// produces the same bytes of the upstream collections.PairKeyCodec(sdk.AccAddressKey, collections.StringKey).
+func FromUpstreamNonTerminalKeyCodec[K any](kc collcodec.KeyCodec[K]) KeyEncoder[K] {
+	return upstreamKeyEncoder[K]{kc: kc, nonTerminal: true}
+}
+
+// FromUpstreamValueCodec adapts a cosmossdk.io/collections ValueCodec into a ValueEncoder.
+func FromUpstreamValueCodec[V any](vc collcodec.ValueCodec[V]) ValueEncoder[V] {
+	return upstreamValueEncoder[V]{vc: vc}
+}
+
+// ToUpstreamKeyCodec adapts a KeyEncoder into a cosmossdk.io/collections KeyCodec.
+// KeyEncoders have a single encoding, which is used both as terminal and non terminal
+// encoding. The JSON representation of the key is the base64 string of its bytes.
+func ToUpstreamKeyCodec[K any](kc KeyEncoder[K]) collcodec.KeyCodec[K] {
+	return upstreamKeyCodec[K]{kc: kc}
+}
+
+// ToUpstreamValueCodec adapts a ValueEncoder into a cosmossdk.io/collections ValueCodec.
+// The JSON representation of the value is the base64 string of its bytes.
+func ToUpstreamValueCodec[V any](vc ValueEncoder[V]) collcodec.ValueCodec[V] {
+	return upstreamValueCodec[V]{vc: vc}
+}
+
+type upstreamKeyEncoder[K any] struct {
+	kc          collcodec.KeyCodec[K]
+	nonTerminal bool
+}
+
+func (u upstreamKeyEncoder[K]) Stringify(key K) string { return u.kc.Stringify(key) }
+
+func (u upstreamKeyEncoder[K]) Encode(key K) []byte {
+	buf := make([]byte, u.Size(key))
+	return buf[:u.PutKey(buf, key)]
+}
+
+func (u upstreamKeyEncoder[K]) Decode(b []byte) (int, K) {
+	var (
+		read int
+		key  K
+		err  error
+	)
+	if u.nonTerminal {
+		read, key, err = u.kc.DecodeNonTerminal(b)
+	} else {
+		read, key, err = u.kc.Decode(b)
+	}
+	if err != nil {
+		panic(wrapEncodingError(err))
+	}
+	return read, key
+}
+
+func (u upstreamKeyEncoder[K]) Size(key K) int {
+	if u.nonTerminal {
+		return u.kc.SizeNonTerminal(key)
+	}
+	return u.kc.Size(key)
+}
+
+func (u upstreamKeyEncoder[K]) PutKey(buf []byte, key K) int {
+	var (
+		n   int
+		err error
+	)
+	if u.nonTerminal {
+		n, err = u.kc.EncodeNonTerminal(buf, key)
+	} else {
+		n, err = u.kc.Encode(buf, key)
+	}
+	if err != nil {
+		panic(wrapEncodingError(err))
+	}
+	return n
+}
+
+type upstreamValueEncoder[V any] struct {
+	vc collcodec.ValueCodec[V]
+}
+
+func (u upstreamValueEncoder[V]) Stringify(value V) string { return u.vc.Stringify(value) }
+func (u upstreamValueEncoder[V]) Name() string             { return u.vc.ValueType() }
+
+func (u upstreamValueEncoder[V]) Encode(value V) []byte {
+	b, err := u.vc.Encode(value)
+	if err != nil {
+		panic(wrapEncodingError(err))
+	}
+	return b
+}
+
+func (u upstreamValueEncoder[V]) Decode(b []byte) V {
+	v, err := u.vc.Decode(b)
+	if err != nil {
+		panic(wrapEncodingError(err))
+	}
+	return v
+}
+
+type upstreamKeyCodec[K any] struct {
+	kc KeyEncoder[K]
+}
+
+func (u upstreamKeyCodec[K]) Encode(buffer []byte, key K) (n int, err error) {
+	defer recoverEncoding(&err)
+	if sized, ok := u.kc.(SizedKeyEncoder[K]); ok {
+		return sized.PutKey(buffer, key), nil
+	}
+	b := u.kc.Encode(key)
+	if len(buffer) < len(b) {
+		return 0, fmt.Errorf("%w: buffer of size %d is too small for key %s of size %d", ErrEncoding, len(buffer), u.kc.Stringify(key), len(b))
+	}
+	return copy(buffer, b), nil
+}
+
+func (u upstreamKeyCodec[K]) Decode(buffer []byte) (int, K, error) {
+	return KeyCodecFromEncoder(u.kc).Decode(buffer)
+}
+
+// Size cannot return an error, so it panics with an error
+// wrapping ErrEncoding if the key cannot be encoded.
+func (u upstreamKeyCodec[K]) Size(key K) int {
+	n, err := u.size(key)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (u upstreamKeyCodec[K]) size(key K) (n int, err error) {
+	defer recoverEncoding(&err)
+	if sized, ok := u.kc.(SizedKeyEncoder[K]); ok {
+		return sized.Size(key), nil
+	}
+	return len(u.kc.Encode(key)), nil
+}
+
+func (u upstreamKeyCodec[K]) EncodeJSON(key K) ([]byte, error) {
+	b, err := KeyCodecFromEncoder(u.kc).Encode(key)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(b)
+}
+
+func (u upstreamKeyCodec[K]) DecodeJSON(b []byte) (key K, err error) {
+	var bz []byte
+	if err = json.Unmarshal(b, &bz); err != nil {
+		return key, fmt.Errorf("%w: %w", ErrEncoding, err)
+	}
+	read, key, err := KeyCodecFromEncoder(u.kc).Decode(bz)
+	if err != nil {
+		return key, err
+	}
+	if read != len(bz) {
+		return key, fmt.Errorf("%w: key decoder didn't fully consume the key: %T %x %d", ErrEncoding, u.kc, bz, read)
+	}
+	return key, nil
+}
+
+func (u upstreamKeyCodec[K]) Stringify(key K) string { return u.kc.Stringify(key) }
+func (u upstreamKeyCodec[K]) KeyType() string        { return fmt.Sprintf("%T", *new(K)) }
+
+func (u upstreamKeyCodec[K]) EncodeNonTerminal(buffer []byte, key K) (int, error) {
+	return u.Encode(buffer, key)
+}
+
+func (u upstreamKeyCodec[K]) DecodeNonTerminal(buffer []byte) (int, K, error) {
+	return u.Decode(buffer)
+}
+
+func (u upstreamKeyCodec[K]) SizeNonTerminal(key K) int { return u.Size(key) }
+
+type upstreamValueCodec[V any] struct {
+	vc ValueEncoder[V]
+}
+
+func (u upstreamValueCodec[V]) Encode(value V) ([]byte, error) {
+	return ValueCodecFromEncoder(u.vc).Encode(value)
+}
+
+func (u upstreamValueCodec[V]) Decode(b []byte) (V, error) {
+	return ValueCodecFromEncoder(u.vc).Decode(b)
+}
+
+func (u upstreamValueCodec[V]) EncodeJSON(value V) ([]byte, error) {
+	b, err := u.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(b)
+}
+
+func (u upstreamValueCodec[V]) DecodeJSON(b []byte) (value V, err error) {
+	var bz []byte
+	if err = json.Unmarshal(b, &bz); err != nil {
+		return value, fmt.Errorf("%w: %w", ErrEncoding, err)
+	}
+	return u.Decode(bz)
+}
+
+func (u upstreamValueCodec[V]) Stringify(value V) string { return u.vc.Stringify(value) }
+func (u upstreamValueCodec[V]) ValueType() string        { return u.vc.Name() }
@@ -0,0 +1,166 @@
+package collections
+
+import (
+	"testing"
+
+	sdkcollections "cosmossdk.io/collections"
+	collcodec "cosmossdk.io/collections/codec"
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInteropToUpstream(t *testing.T) {
+	sk, ctx, _ := deps()
+	sb := sdkcollections.NewSchemaBuilder(kvStoreService{sk})
+
+	kc := PairKeyEncoder(StringKeyEncoder, Uint64KeyEncoder)
+	m := NewMap(sk, 1, kc, IntValueEncoder)
+	upstream := sdkcollections.NewMap(sb, UpstreamPrefix(1), "upstream", ToUpstreamKeyCodec(kc), ToUpstreamValueCodec(IntValueEncoder))
+	_, err := sb.Build()
+	require.NoError(t, err)
+
+	// written natively, read from upstream
+	m.Insert(ctx, Join("a", uint64(1)), math.NewInt(100))
+	v, err := upstream.Get(ctx, Join("a", uint64(1)))
+	require.NoError(t, err)
+	require.Equal(t, math.NewInt(100), v)
+
+	// written from upstream, read natively
+	require.NoError(t, upstream.Set(ctx, Join("b", uint64(2)), math.NewInt(200)))
+	require.Equal(t, math.NewInt(200), m.GetOr(ctx, Join("b", uint64(2)), math.ZeroInt()))
+
+	// json
+	ukc := ToUpstreamKeyCodec(kc)
+	bz, err := ukc.EncodeJSON(Join("a", uint64(1)))
+	require.NoError(t, err)
+	k, err := ukc.DecodeJSON(bz)
+	require.NoError(t, err)
+	require.Equal(t, Join("a", uint64(1)), k)
+}
+
+func TestInteropSingleKeyEncoding(t *testing.T) {
+	encode := func(kc collcodec.KeyCodec[string], key string) []byte {
+		b := make([]byte, kc.Size(key))
+		n, err := kc.Encode(b, key)
+		require.NoError(t, err)
+		return b[:n]
+	}
+	nonTerminal := func(kc collcodec.KeyCodec[string], key string) []byte {
+		b := make([]byte, kc.SizeNonTerminal(key))
+		n, err := kc.EncodeNonTerminal(b, key)
+		require.NoError(t, err)
+		return b[:n]
+	}
+
+	// upstream encodes the only key of a collection with the terminal encoding,
+	// which StringKeyEncoder does not match.
+	require.NotEqual(t, encode(sdkcollections.StringKey, "atom"), StringKeyEncoder.Encode("atom"))
+	require.Equal(t, encode(sdkcollections.StringKey, "atom"), TerminalStringKeyEncoder.Encode("atom"))
+	require.Equal(t, encode(sdkcollections.StringKey, "atom"), FromUpstreamKeyCodec(sdkcollections.StringKey).Encode("atom"))
+	require.Equal(t, nonTerminal(sdkcollections.StringKey, "atom"), StringKeyEncoder.Encode("atom"))
+
+	addr := sdk.AccAddress("address")
+	upstreamAddr := make([]byte, sdk.AccAddressKey.Size(addr))
+	_, err := sdk.AccAddressKey.Encode(upstreamAddr, addr)
+	require.NoError(t, err)
+	require.NotEqual(t, upstreamAddr, AccAddressBytesKeyEncoder.Encode(addr))
+	require.Equal(t, upstreamAddr, FromUpstreamKeyCodec(sdk.AccAddressKey).Encode(addr))
+}
+
+func TestInteropToUpstreamKeyCodecErrors(t *testing.T) {
+	// the size of a failing key is reported by panicking
+	ukc := ToUpstreamKeyCodec(KeyEncoderFromCodec[string](failingKeyCodec{}))
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			require.ErrorIs(t, err, ErrEncoding)
+			require.ErrorIs(t, err, errFailingCodec)
+		}()
+		ukc.Size("a")
+	}()
+	_, err := ukc.Encode(make([]byte, 8), "a")
+	require.ErrorIs(t, err, ErrEncoding)
+
+	// incomplete composite keys cannot be sized
+	pair := ToUpstreamKeyCodec(PairKeyEncoder(StringKeyEncoder, Uint64KeyEncoder))
+	require.Panics(t, func() { pair.Size(Pair[string, uint64]{}) })
+
+	// too small buffers are an error
+	str := ToUpstreamKeyCodec(KeyEncoderFromCodec(KeyCodecFromEncoder(StringKeyEncoder)))
+	require.Equal(t, len(StringKeyEncoder.Encode("abc")), str.Size("abc"))
+	_, err = str.Encode(make([]byte, 1), "abc")
+	require.ErrorIs(t, err, ErrEncoding)
+}
+
+func TestInteropFromUpstream(t *testing.T) {
+	sk, ctx, _ := deps()
+	sb := sdkcollections.NewSchemaBuilder(kvStoreService{sk})
+
+	upstream := sdkcollections.NewMap(
+		sb, UpstreamPrefix(1), "upstream",
+		sdkcollections.PairKeyCodec(sdk.AccAddressKey, sdkcollections.StringKey),
+		sdkcollections.Uint64Value,
+	)
+	_, err := sb.Build()
+	require.NoError(t, err)
+
+	kc := PairKeyEncoder(
+		FromUpstreamNonTerminalKeyCodec(sdk.AccAddressKey),
+		FromUpstreamKeyCodec(collcodec.NewStringKeyCodec[string]()),
+	)
+	m := NewMap(sk, 1, kc, FromUpstreamValueCodec(sdkcollections.Uint64Value))
+
+	addr := sdk.AccAddress("address")
+	require.NoError(t, upstream.Set(ctx, sdkcollections.Join(addr, "denom"), 10))
+	require.Equal(t, uint64(10), m.GetOr(ctx, Join(addr, "denom"), 0))
+
+	m.Insert(ctx, Join(addr, "other"), 20)
+	v, err := upstream.Get(ctx, sdkcollections.Join(addr, "other"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), v)
+
+	// the native address encoder and terminal string encoder are byte compatible
+	native := NewMap(sk, 1, PairKeyEncoder(AccAddressBytesKeyEncoder, TerminalStringKeyEncoder), FromUpstreamValueCodec(sdkcollections.Uint64Value))
+	require.Equal(t, uint64(10), native.GetOr(ctx, Join(addr, "denom"), 0))
+	require.Equal(t, uint64(20), native.GetOr(ctx, Join(addr, "other"), 0))
+}
+
+func TestInteropEncodingCompatibility(t *testing.T) {
+	upstreamBytes := func(kc collcodec.KeyCodec[string], nonTerminal bool, key string) []byte {
+		if nonTerminal {
+			b := make([]byte, kc.SizeNonTerminal(key))
+			_, err := kc.EncodeNonTerminal(b, key)
+			require.NoError(t, err)
+			return b
+		}
+		b := make([]byte, kc.Size(key))
+		_, err := kc.Encode(b, key)
+		require.NoError(t, err)
+		return b
+	}
+
+	require.Equal(t, upstreamBytes(sdkcollections.StringKey, true, "hello"), StringKeyEncoder.Encode("hello"))
+	require.Equal(t, upstreamBytes(sdkcollections.StringKey, false, "hello"), TerminalStringKeyEncoder.Encode("hello"))
+
+	require.Equal(t, upstreamEncodeKey(t, sdkcollections.Int64Key, int64(-10)), Int64KeyEncoder.Encode(-10))
+	require.Equal(t, upstreamEncodeKey(t, sdkcollections.Int32Key, int32(-10)), Int32KeyEncoder.Encode(-10))
+	require.Equal(t, upstreamEncodeKey(t, sdkcollections.Uint64Key, uint64(10)), Uint64KeyEncoder.Encode(10))
+	require.Equal(t, upstreamEncodeKey(t, sdkcollections.Uint32Key, uint32(10)), Uint32KeyEncoder.Encode(10))
+	require.Equal(t, upstreamEncodeKey(t, sdkcollections.Uint16Key, uint16(10)), Uint16KeyEncoder.Encode(10))
+	require.Equal(t, upstreamEncodeKey(t, sdkcollections.BoolKey, true), BoolKeyEncoder.Encode(true))
+
+	require.Equal(t, []byte{1}, []byte(UpstreamPrefix(1)))
+}
+
+func TestTerminalStringKey(t *testing.T) {
+	assertBijective(t, TerminalStringKeyEncoder, "terminal")
+	assertBijective(t, TerminalStringKeyEncoder, "")
+}
+
+func upstreamEncodeKey[K any](t *testing.T, kc collcodec.KeyCodec[K], key K) []byte {
+	b := make([]byte, kc.Size(key))
+	_, err := kc.Encode(b, key)
+	require.NoError(t, err)
+	return b
+}
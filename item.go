@@ -1,8 +1,9 @@
 package collections
 
 import (
+	"context"
+
 	storetypes "cosmossdk.io/store/types"
-	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // itemKey is a constant byte key which maps an Item object.
@@ -13,6 +14,12 @@ func NewItem[V any](sk storetypes.StoreKey, namespace Namespace, valueEncoder Va
 	return (Item[V])(NewMap[uint64, V](sk, namespace, uint64Key{}, valueEncoder))
 }
 
+// NewItemWithStore instantiates a new Item instance which reads from and writes to
+// the KVStore provided by the StoreAccessor.
+func NewItemWithStore[V any](sa StoreAccessor, namespace Namespace, valueEncoder ValueEncoder[V]) Item[V] {
+	return (Item[V])(NewMapWithStore[uint64, V](sa, namespace, uint64Key{}, valueEncoder))
+}
+
 // Item represents a state object which will always have one instance
 // of itself saved in the namespace.
 // Examples are:
@@ -24,14 +31,16 @@ func NewItem[V any](sk storetypes.StoreKey, namespace Namespace, valueEncoder Va
 type Item[V any] Map[uint64, V]
 
 // Get gets the item V or returns an error.
-func (i Item[V]) Get(ctx sdk.Context) (V, error) { return (Map[uint64, V])(i).Get(ctx, itemKey) }
+func (i Item[V]) Get(ctx context.Context) (V, error) { return (Map[uint64, V])(i).Get(ctx, itemKey) }
 
 // GetOr either returns the provided default
 // if it's not present in state, or the value found in state.
-func (i Item[V]) GetOr(ctx sdk.Context, def V) V { return (Map[uint64, V])(i).GetOr(ctx, itemKey, def) }
+func (i Item[V]) GetOr(ctx context.Context, def V) V {
+	return (Map[uint64, V])(i).GetOr(ctx, itemKey, def)
+}
 
 // Set sets the item value to v.
-func (i Item[V]) Set(ctx sdk.Context, v V) { (Map[uint64, V])(i).Insert(ctx, itemKey, v) }
+func (i Item[V]) Set(ctx context.Context, v V) { (Map[uint64, V])(i).Insert(ctx, itemKey, v) }
 
 // NewItem instantiates a new Item instance.
 func NewItemTransient[V any](
@@ -53,17 +62,17 @@ func NewItemTransient[V any](
 // persistent cost) and a read cost per byte of zero.
 type ItemTransient[V any] MapTransient[uint64, V]
 
-func (i ItemTransient[V]) Get(ctx sdk.Context) (V, error) {
+func (i ItemTransient[V]) Get(ctx context.Context) (V, error) {
 	return (MapTransient[uint64, V])(i).Get(ctx, itemKey)
 }
 
 // GetOr either returns the provided default
 // if it's not present in state, or the value found in state.
-func (i ItemTransient[V]) GetOr(ctx sdk.Context, def V) V {
+func (i ItemTransient[V]) GetOr(ctx context.Context, def V) V {
 	return (MapTransient[uint64, V])(i).GetOr(ctx, itemKey, def)
 }
 
 // Set sets the item value to v.
-func (i ItemTransient[V]) Set(ctx sdk.Context, v V) {
+func (i ItemTransient[V]) Set(ctx context.Context, v V) {
 	(MapTransient[uint64, V])(i).Insert(ctx, itemKey, v)
 }
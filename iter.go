@@ -1,6 +1,7 @@
 package collections
 
 import (
+	"bytes"
 	"fmt"
 
 	store "cosmossdk.io/store"
@@ -42,6 +43,10 @@ func BoundExclusive[K any](key K) *Bound[K] {
 type Bound[K any] struct {
 	value     K
 	inclusive bool
+	// partial is set when the bound value is only the first part of the keys
+	// it refers to, inclusivity and exclusivity then apply to all the keys
+	// starting with the bound value.
+	partial bool
 }
 
 // Ranger defines a generic interface that provides a range of keys.
@@ -106,51 +111,118 @@ func (r Range[K]) RangeValues() (prefix *K, start *Bound[K], end *Bound[K], orde
 
 // iteratorFromRange generates an Iterator instance, with the proper prefixing and ranging.
 func iteratorFromRange[K, V any](s store.KVStore, r Ranger[K], kc KeyEncoder[K], vc ValueEncoder[V]) Iterator[K, V] {
+	rr := rawRangeFromRanger(s, r, kc)
+	return iteratorFromRawRange[K, V](rr, kc, vc)
+}
+
+// rawRange is the encoded form of a Ranger.
+type rawRange struct {
+	// store is prefixed with prefixBytes.
+	store       store.KVStore
+	prefixBytes []byte
+	// start and end are relative to prefixBytes.
+	start, end []byte
+	order      Order
+	// empty is true when the range cannot contain any key.
+	empty bool
+}
+
+// rawRangeFromRanger encodes the provided Ranger.
+func rawRangeFromRanger[K any](s store.KVStore, r Ranger[K], kc KeyEncoder[K]) rawRange {
 	pfx, start, end, order := r.RangeValues()
 	var prefixBytes []byte
 	if pfx != nil {
-		prefixBytes = kc.Encode(*pfx)
+		prefixBytes = encodeKey(kc, *pfx)
 		s = prefix.NewStore(s, prefixBytes)
 	}
-	var startBytes []byte // default is nil
+	var (
+		startBytes []byte // default is nil
+		empty      bool
+	)
 	if start != nil {
-		startBytes = kc.Encode(start.value)
+		startBytes = encodeKey(kc, start.value)
 		// iterators are inclusive at start by default
 		// so if we want to make the iteration exclusive
-		// we extend by one byte.
+		// we extend by one byte, or skip every key starting
+		// with the bound if the bound is partial.
 		if !start.inclusive {
-			startBytes = extendOneByte(startBytes)
+			if start.partial {
+				startBytes = storetypes.PrefixEndBytes(startBytes)
+				// there is no key after a prefix made only of 0xFF bytes,
+				// a nil start would instead mean from the first key.
+				empty = startBytes == nil
+			} else {
+				startBytes = extendOneByte(startBytes)
+			}
 		}
 	}
 	var endBytes []byte // default is nil
 	if end != nil {
-		endBytes = kc.Encode(end.value)
+		endBytes = encodeKey(kc, end.value)
 		// iterators are exclusive at end by default
 		// so if we want to make the iteration
-		// inclusive we need to extend by one byte.
+		// inclusive we need to extend by one byte,
+		// or include every key starting with the
+		// bound if the bound is partial.
 		if end.inclusive {
-			endBytes = extendOneByte(endBytes)
+			if end.partial {
+				endBytes = storetypes.PrefixEndBytes(endBytes)
+			} else {
+				endBytes = extendOneByte(endBytes)
+			}
 		}
 	}
 
+	return rawRange{
+		store:       s,
+		prefixBytes: prefixBytes,
+		start:       startBytes,
+		end:         endBytes,
+		order:       order,
+		empty:       empty,
+	}
+}
+
+// iteratorFromRawRange generates an Iterator instance over the provided rawRange.
+func iteratorFromRawRange[K, V any](r rawRange, kc KeyEncoder[K], vc ValueEncoder[V]) Iterator[K, V] {
 	var iter storetypes.Iterator
-	switch order {
-	case OrderAscending:
-		iter = s.Iterator(startBytes, endBytes)
-	case OrderDescending:
-		iter = s.ReverseIterator(startBytes, endBytes)
+	switch {
+	case r.empty:
+		iter = emptyIterator{}
+	case r.order == OrderAscending:
+		iter = r.store.Iterator(r.start, r.end)
+	case r.order == OrderDescending:
+		iter = r.store.ReverseIterator(r.start, r.end)
 	default:
-		panic(fmt.Errorf("unrecognized Order: %v", order))
+		panic(fmt.Errorf("unrecognized Order: %v", r.order))
 	}
 
-	return Iterator[K, V]{
+	it := Iterator[K, V]{
 		kc:          kc,
 		vc:          vc,
 		iter:        iter,
-		prefixBytes: prefixBytes,
+		prefixBytes: r.prefixBytes,
+		order:       r.order,
+	}
+	// the keys decoded by other KeyEncoders might retain the
+	// buffer, so they are decoded from a new copy every time.
+	if decodeCopies(kc) {
+		it.scratch = new([]byte)
 	}
+	return it
 }
 
+// emptyIterator is a storetypes.Iterator over no keys.
+type emptyIterator struct{}
+
+func (emptyIterator) Domain() (start, end []byte) { return nil, nil }
+func (emptyIterator) Valid() bool                 { return false }
+func (emptyIterator) Next()                       { panic("collections: Next called on an invalid iterator") }
+func (emptyIterator) Key() []byte                 { panic("collections: Key called on an invalid iterator") }
+func (emptyIterator) Value() []byte               { panic("collections: Value called on an invalid iterator") }
+func (emptyIterator) Error() error                { return nil }
+func (emptyIterator) Close() error                { return nil }
+
 // Iterator defines a generic wrapper around an storetypes.Iterator.
 // This iterator provides automatic key and value encoding,
 // it assumes all the keys and values contained within the storetypes.Iterator
@@ -162,16 +234,59 @@ type Iterator[K, V any] struct {
 	iter storetypes.Iterator
 
 	prefixBytes []byte
+	order       Order
+	// scratch is the buffer reused to join prefixBytes and the
+	// storetypes.Iterator keys before decoding, it is nil if
+	// the decoded keys might retain the buffer.
+	scratch *[]byte
+	// decoded provides the current entry when a combinator
+	// already decoded it, it is nil otherwise.
+	decoded decodedEntry[K, V]
 }
 
 // Value returns the current iterator value bytes decoded.
 func (i Iterator[K, V]) Value() V {
+	if i.decoded != nil {
+		if _, v, hasValue := i.decoded.decodedEntry(); hasValue {
+			return v
+		}
+	}
 	return i.vc.Decode(i.iter.Value())
 }
 
+// RawValue returns the current encoded value, without decoding it.
+// The returned bytes must not be modified.
+func (i Iterator[K, V]) RawValue() []byte {
+	return i.iter.Value()
+}
+
+// LazyValue returns the current encoded value,
+// which is decoded only once LazyValue.Value is called.
+func (i Iterator[K, V]) LazyValue() LazyValue[V] {
+	return LazyValue[V]{
+		raw: bytes.Clone(i.iter.Value()),
+		vc:  i.vc,
+	}
+}
+
+// SafeValue works like Value, but instead of panicking when
+// the value cannot be decoded it returns an error wrapping ErrEncoding.
+func (i Iterator[K, V]) SafeValue() (V, error) {
+	return ValueCodecFromEncoder(i.vc).Decode(i.iter.Value())
+}
+
 // Key returns the current storetypes.Iterator decoded key.
 func (i Iterator[K, V]) Key() K {
-	rawKey := append(i.prefixBytes, i.iter.Key()...)
+	if i.decoded != nil {
+		k, _, _ := i.decoded.decodedEntry()
+		return k
+	}
+	return i.decodeKey(i.iter.Key())
+}
+
+// decodeKey decodes the provided storetypes.Iterator key, which is relative to the range prefix.
+func (i Iterator[K, V]) decodeKey(key []byte) K {
+	rawKey := i.joinPrefix(key)
 	read, c := i.kc.Decode(rawKey)
 	if read != len(rawKey) {
 		panic(fmt.Sprintf("key decoder didn't fully consume the key: %T %x %d", i.kc, rawKey, read))
@@ -179,6 +294,36 @@ func (i Iterator[K, V]) Key() K {
 	return c
 }
 
+// SafeKey works like Key, but instead of panicking when
+// the key cannot be decoded it returns an error wrapping ErrEncoding.
+func (i Iterator[K, V]) SafeKey() (k K, err error) {
+	rawKey := i.joinPrefix(i.iter.Key())
+	read, k, err := KeyCodecFromEncoder(i.kc).Decode(rawKey)
+	if err != nil {
+		return k, err
+	}
+	if read != len(rawKey) {
+		return k, fmt.Errorf("%w: key decoder didn't fully consume the key: %T %x %d", ErrEncoding, i.kc, rawKey, read)
+	}
+	return k, nil
+}
+
+// joinPrefix returns the provided storetypes.Iterator key prefixed with prefixBytes.
+// If the iterator has a scratch buffer, the returned bytes are only valid until the
+// next call, otherwise they are a new copy. They never alias prefixBytes.
+func (i Iterator[K, V]) joinPrefix(key []byte) []byte {
+	if i.scratch == nil {
+		rawKey := make([]byte, 0, len(i.prefixBytes)+len(key))
+		return append(append(rawKey, i.prefixBytes...), key...)
+	}
+	if len(i.prefixBytes) == 0 {
+		return key
+	}
+	buf := append(append((*i.scratch)[:0], i.prefixBytes...), key...)
+	*i.scratch = buf
+	return buf
+}
+
 // Values fully consumes the iterator and returns all the decoded values contained within the range.
 func (i Iterator[K, V]) Values() []V {
 	defer i.Close()
@@ -201,6 +346,25 @@ func (i Iterator[K, V]) Keys() []K {
 	return keys
 }
 
+// rawKeys fully consumes the iterator and returns all the encoded keys contained within the range.
+func (i Iterator[K, V]) rawKeys() [][]byte {
+	defer i.Close()
+
+	var keys [][]byte
+	for ; i.iter.Valid(); i.iter.Next() {
+		keys = append(keys, i.RawKey())
+	}
+	return keys
+}
+
+// RawKey returns a copy of the current encoded key, relative to the collection
+// namespace, without decoding it.
+func (i Iterator[K, V]) RawKey() []byte {
+	key := i.iter.Key()
+	rawKey := make([]byte, 0, len(i.prefixBytes)+len(key))
+	return append(append(rawKey, i.prefixBytes...), key...)
+}
+
 // KeyValue returns the current key and value decoded.
 func (i Iterator[K, V]) KeyValue() KeyValue[K, V] {
 	return KeyValue[K, V]{
@@ -225,11 +389,42 @@ func (i Iterator[K, V]) Close()      { _ = i.iter.Close() }
 func (i Iterator[K, V]) Next()       { i.iter.Next() }
 func (i Iterator[K, V]) Valid() bool { return i.iter.Valid() }
 
+// LazyKeyValues fully consumes the iterator and returns the list of keys within the
+// iterator range, paired with their values which are decoded only when requested.
+func (i Iterator[K, V]) LazyKeyValues() []KeyValue[K, LazyValue[V]] {
+	defer i.Close()
+
+	var kvs []KeyValue[K, LazyValue[V]]
+	for ; i.iter.Valid(); i.iter.Next() {
+		kvs = append(kvs, KeyValue[K, LazyValue[V]]{
+			Key:   i.Key(),
+			Value: i.LazyValue(),
+		})
+	}
+	return kvs
+}
+
 type KeyValue[K, V any] struct {
 	Key   K
 	Value V
 }
 
+// LazyValue holds an encoded value, which is decoded only when requested.
+type LazyValue[V any] struct {
+	raw []byte
+	vc  ValueEncoder[V]
+}
+
+// Raw returns the encoded value.
+func (l LazyValue[V]) Raw() []byte { return l.raw }
+
+// Value decodes the value.
+func (l LazyValue[V]) Value() V { return l.vc.Decode(l.raw) }
+
+// SafeValue works like Value, but instead of panicking when
+// the value cannot be decoded it returns an error wrapping ErrEncoding.
+func (l LazyValue[V]) SafeValue() (V, error) { return ValueCodecFromEncoder(l.vc).Decode(l.raw) }
+
 func extendOneByte(b []byte) []byte {
 	return append(b, 0)
 }
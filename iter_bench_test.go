@@ -0,0 +1,40 @@
+package collections
+
+import (
+	"testing"
+)
+
+// benchmarkPairMap fills a Map with n Pair keys sharing the same
+// first part, and returns a function iterating over them.
+func benchmarkPairMap(n uint64) func() Iterator[Pair[string, uint64], uint64] {
+	sk, ctx, _ := deps()
+	m := NewMap[Pair[string, uint64], uint64](sk, 0, PairKeyEncoder(StringKeyEncoder, Uint64KeyEncoder), uint64Value{})
+	for i := uint64(0); i < n; i++ {
+		m.Insert(ctx, Join("prefix", i), i)
+	}
+	return func() Iterator[Pair[string, uint64], uint64] {
+		return m.Iterate(ctx, PairRange[string, uint64]{}.Prefix("prefix"))
+	}
+}
+
+func BenchmarkIteratorKeysPair(b *testing.B) {
+	iterate := benchmarkPairMap(10_000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if len(iterate().Keys()) != 10_000 {
+			b.Fatal("unexpected number of keys")
+		}
+	}
+}
+
+func BenchmarkIteratorKeyValuesPair(b *testing.B) {
+	iterate := benchmarkPairMap(10_000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if len(iterate().KeyValues()) != 10_000 {
+			b.Fatal("unexpected number of key values")
+		}
+	}
+}
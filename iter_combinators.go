@@ -0,0 +1,282 @@
+package collections
+
+import (
+	"bytes"
+	"fmt"
+
+	storetypes "cosmossdk.io/store/types"
+)
+
+// Filter returns an Iterator which provides only the key-value pairs for which keep
+// returns true. Filtering is lazy, the pairs are decoded only when the Iterator
+// moves onto them, and the pair passed to keep is the one then returned by Key,
+// Value and KeyValue, without decoding it again. The returned Iterator shares its
+// state with i, so only one of them must be used.
+func (i Iterator[K, V]) Filter(keep func(key K, value V) bool) Iterator[K, V] {
+	filter := newFilterIterator(i.iter, i.decodeKey, i.vc.Decode, keep)
+	i.iter, i.decoded = filter, filter
+	return i
+}
+
+// Take returns an Iterator which provides at most the first n key-value pairs of i.
+// The returned Iterator shares its state with i, so only one of them must be used.
+func (i Iterator[K, V]) Take(n int) Iterator[K, V] {
+	i.iter = &takeIterator{Iterator: i.iter, remaining: n}
+	return i
+}
+
+// Skip moves the Iterator past its first n key-value pairs, without decoding them.
+func (i Iterator[K, V]) Skip(n int) Iterator[K, V] {
+	for ; n > 0 && i.iter.Valid(); n-- {
+		i.iter.Next()
+	}
+	return i
+}
+
+// First returns the first key-value pair of the Iterator, if any.
+// The Iterator is closed after this operation.
+func (i Iterator[K, V]) First() (kv KeyValue[K, V], found bool) {
+	defer i.Close()
+
+	if !i.iter.Valid() {
+		return kv, false
+	}
+	return i.KeyValue(), true
+}
+
+// Last returns the last key-value pair of the Iterator, if any, only the
+// last pair is decoded. The Iterator is closed after this operation.
+func (i Iterator[K, V]) Last() (kv KeyValue[K, V], found bool) {
+	defer i.Close()
+
+	var key, value []byte
+	for ; i.iter.Valid(); i.iter.Next() {
+		key, value, found = bytes.Clone(i.iter.Key()), bytes.Clone(i.iter.Value()), true
+	}
+	if !found {
+		return kv, false
+	}
+	return KeyValue[K, V]{Key: i.decodeKey(key), Value: i.vc.Decode(value)}, true
+}
+
+// Count fully consumes the Iterator and returns the number of key-value pairs
+// it provided, without decoding them. The Iterator is closed after this operation.
+func (i Iterator[K, V]) Count() int {
+	defer i.Close()
+
+	n := 0
+	for ; i.iter.Valid(); i.iter.Next() {
+		n++
+	}
+	return n
+}
+
+// Any reports whether any key-value pair of the Iterator satisfies the predicate,
+// it stops at the first one which does. The Iterator is closed after this operation.
+func (i Iterator[K, V]) Any(predicate func(key K, value V) bool) bool {
+	defer i.Close()
+
+	for ; i.iter.Valid(); i.iter.Next() {
+		if predicate(i.Key(), i.Value()) {
+			return true
+		}
+	}
+	return false
+}
+
+// MapValues returns an Iterator which provides the values of i transformed by the
+// mapping function. Values are mapped lazily, when they are requested. The returned
+// Iterator shares its state with i, so only one of them must be used.
+func MapValues[K, V, V2 any](i Iterator[K, V], mapping func(V) V2) Iterator[K, V2] {
+	mapped := Iterator[K, V2]{
+		kc:          i.kc,
+		vc:          mappedValueEncoder[V, V2]{vc: i.vc, mapping: mapping},
+		iter:        i.iter,
+		prefixBytes: i.prefixBytes,
+		order:       i.order,
+		scratch:     i.scratch,
+	}
+	if i.decoded != nil {
+		mapped.decoded = mappedEntry[K, V, V2]{entry: i.decoded, mapping: mapping}
+	}
+	return mapped
+}
+
+// Filter returns a KeySetIterator which provides only the keys for which keep returns true.
+// The returned KeySetIterator shares its state with s, so only one of them must be used.
+func (s KeySetIterator[K]) Filter(keep func(key K) bool) KeySetIterator[K] {
+	i := (Iterator[K, setObject])(s)
+	filter := newFilterIterator(i.iter, i.decodeKey, nil, func(key K, _ setObject) bool { return keep(key) })
+	i.iter, i.decoded = filter, filter
+	return (KeySetIterator[K])(i)
+}
+
+// Take returns a KeySetIterator which provides at most the first n keys of s.
+// The returned KeySetIterator shares its state with s, so only one of them must be used.
+func (s KeySetIterator[K]) Take(n int) KeySetIterator[K] {
+	return (KeySetIterator[K])((Iterator[K, setObject])(s).Take(n))
+}
+
+// Skip moves the KeySetIterator past its first n keys, without decoding them.
+func (s KeySetIterator[K]) Skip(n int) KeySetIterator[K] {
+	return (KeySetIterator[K])((Iterator[K, setObject])(s).Skip(n))
+}
+
+// First returns the first key of the KeySetIterator, if any.
+// The KeySetIterator is closed after this operation.
+func (s KeySetIterator[K]) First() (K, bool) {
+	kv, found := (Iterator[K, setObject])(s).First()
+	return kv.Key, found
+}
+
+// Last returns the last key of the KeySetIterator, if any.
+// The KeySetIterator is closed after this operation.
+func (s KeySetIterator[K]) Last() (K, bool) {
+	kv, found := (Iterator[K, setObject])(s).Last()
+	return kv.Key, found
+}
+
+// Count fully consumes the KeySetIterator and returns the number of keys it provided,
+// without decoding them. The KeySetIterator is closed after this operation.
+func (s KeySetIterator[K]) Count() int { return (Iterator[K, setObject])(s).Count() }
+
+// Any reports whether any key of the KeySetIterator satisfies the predicate, it stops
+// at the first one which does. The KeySetIterator is closed after this operation.
+func (s KeySetIterator[K]) Any(predicate func(key K) bool) bool {
+	return (Iterator[K, setObject])(s).Any(func(key K, _ setObject) bool { return predicate(key) })
+}
+
+// Filter returns an IndexerIterator which provides only the joint indexing and primary keys
+// for which keep returns true. The returned IndexerIterator shares its state with i,
+// so only one of them must be used.
+func (i IndexerIterator[IK, PK]) Filter(keep func(key Pair[IK, PK]) bool) IndexerIterator[IK, PK] {
+	return (IndexerIterator[IK, PK])((KeySetIterator[Pair[IK, PK]])(i).Filter(keep))
+}
+
+// Take returns an IndexerIterator which provides at most the first n keys of i.
+// The returned IndexerIterator shares its state with i, so only one of them must be used.
+func (i IndexerIterator[IK, PK]) Take(n int) IndexerIterator[IK, PK] {
+	return (IndexerIterator[IK, PK])((KeySetIterator[Pair[IK, PK]])(i).Take(n))
+}
+
+// Skip moves the IndexerIterator past its first n keys, without decoding them.
+func (i IndexerIterator[IK, PK]) Skip(n int) IndexerIterator[IK, PK] {
+	return (IndexerIterator[IK, PK])((KeySetIterator[Pair[IK, PK]])(i).Skip(n))
+}
+
+// First returns the first joint indexing and primary key of the IndexerIterator, if any.
+// The IndexerIterator is closed after this operation.
+func (i IndexerIterator[IK, PK]) First() (Pair[IK, PK], bool) {
+	return (KeySetIterator[Pair[IK, PK]])(i).First()
+}
+
+// Last returns the last joint indexing and primary key of the IndexerIterator, if any.
+// The IndexerIterator is closed after this operation.
+func (i IndexerIterator[IK, PK]) Last() (Pair[IK, PK], bool) {
+	return (KeySetIterator[Pair[IK, PK]])(i).Last()
+}
+
+// Count fully consumes the IndexerIterator and returns the number of keys it provided,
+// without decoding them. The IndexerIterator is closed after this operation.
+func (i IndexerIterator[IK, PK]) Count() int { return (KeySetIterator[Pair[IK, PK]])(i).Count() }
+
+// Any reports whether any joint indexing and primary key of the IndexerIterator satisfies
+// the predicate, it stops at the first one which does. The IndexerIterator is closed after this operation.
+func (i IndexerIterator[IK, PK]) Any(predicate func(key Pair[IK, PK]) bool) bool {
+	return (KeySetIterator[Pair[IK, PK]])(i).Any(predicate)
+}
+
+// decodedEntry is implemented by the combinators which decode the current entry,
+// which is then returned by the Iterator instead of decoding it again.
+type decodedEntry[K, V any] interface {
+	// decodedEntry returns the key and the value of the current entry,
+	// hasValue is false if only the key was decoded.
+	decodedEntry() (key K, value V, hasValue bool)
+}
+
+// filterIterator is a storetypes.Iterator which skips the entries for which
+// keep returns false. It retains the decoded key and value of the current entry.
+type filterIterator[K, V any] struct {
+	storetypes.Iterator
+	decodeKey   func([]byte) K
+	decodeValue func([]byte) V // nil if keep does not need the value
+	keep        func(key K, value V) bool
+
+	key   K
+	value V
+}
+
+func newFilterIterator[K, V any](
+	it storetypes.Iterator, decodeKey func([]byte) K, decodeValue func([]byte) V, keep func(K, V) bool,
+) *filterIterator[K, V] {
+	f := &filterIterator[K, V]{Iterator: it, decodeKey: decodeKey, decodeValue: decodeValue, keep: keep}
+	f.skip()
+	return f
+}
+
+func (f *filterIterator[K, V]) Next() {
+	f.Iterator.Next()
+	f.skip()
+}
+
+// skip moves the iterator onto the next entry to keep.
+func (f *filterIterator[K, V]) skip() {
+	for ; f.Iterator.Valid(); f.Iterator.Next() {
+		f.key = f.decodeKey(f.Iterator.Key())
+		if f.decodeValue != nil {
+			f.value = f.decodeValue(f.Iterator.Value())
+		}
+		if f.keep(f.key, f.value) {
+			return
+		}
+	}
+}
+
+func (f *filterIterator[K, V]) decodedEntry() (K, V, bool) {
+	return f.key, f.value, f.decodeValue != nil
+}
+
+// mappedEntry maps the value of an entry decoded by another combinator.
+type mappedEntry[K, V, V2 any] struct {
+	entry   decodedEntry[K, V]
+	mapping func(V) V2
+}
+
+func (m mappedEntry[K, V, V2]) decodedEntry() (key K, value V2, hasValue bool) {
+	key, v, hasValue := m.entry.decodedEntry()
+	if hasValue {
+		value = m.mapping(v)
+	}
+	return key, value, hasValue
+}
+
+// takeIterator is a storetypes.Iterator which becomes
+// invalid after providing a number of entries.
+type takeIterator struct {
+	storetypes.Iterator
+	remaining int
+}
+
+func (t *takeIterator) Valid() bool { return t.remaining > 0 && t.Iterator.Valid() }
+
+func (t *takeIterator) Next() {
+	t.remaining--
+	if t.remaining > 0 {
+		t.Iterator.Next()
+	}
+}
+
+// mappedValueEncoder is a decode only ValueEncoder
+// which maps the values decoded by vc.
+type mappedValueEncoder[V, V2 any] struct {
+	vc      ValueEncoder[V]
+	mapping func(V) V2
+}
+
+func (m mappedValueEncoder[V, V2]) Encode(V2) []byte {
+	panic("collections: mapped values cannot be encoded")
+}
+
+func (m mappedValueEncoder[V, V2]) Decode(b []byte) V2    { return m.mapping(m.vc.Decode(b)) }
+func (m mappedValueEncoder[V, V2]) Stringify(v V2) string { return fmt.Sprintf("%v", v) }
+func (m mappedValueEncoder[V, V2]) Name() string          { return m.vc.Name() }
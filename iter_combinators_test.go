@@ -0,0 +1,141 @@
+package collections
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIteratorCombinators(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewMap[uint64, uint64](sk, 0, Uint64KeyEncoder, uint64Value{})
+	for i := uint64(0); i < 10; i++ {
+		m.Insert(ctx, i, i*10)
+	}
+	all := func() Iterator[uint64, uint64] { return m.Iterate(ctx, Range[uint64]{}) }
+	even := func(k, _ uint64) bool { return k%2 == 0 }
+
+	t.Run("filter", func(t *testing.T) {
+		require.Equal(t, []uint64{0, 2, 4, 6, 8}, all().Filter(even).Keys())
+		require.Equal(t, []uint64{90, 70}, m.Iterate(ctx, Range[uint64]{}.Descending()).
+			Filter(func(_, v uint64) bool { return v > 60 }).
+			Filter(func(k, _ uint64) bool { return k != 8 }).Values())
+		require.Empty(t, all().Filter(func(_, _ uint64) bool { return false }).Keys())
+	})
+
+	t.Run("take and skip", func(t *testing.T) {
+		require.Equal(t, []uint64{0, 1, 2}, all().Take(3).Keys())
+		require.Equal(t, []uint64{7, 8, 9}, all().Skip(7).Take(5).Keys())
+		require.Equal(t, []uint64{4, 6}, all().Filter(even).Skip(2).Take(2).Keys())
+		require.Empty(t, all().Take(0).Keys())
+		require.Empty(t, all().Skip(20).Keys())
+	})
+
+	t.Run("map values", func(t *testing.T) {
+		iter := MapValues(all().Take(2), func(v uint64) string { return strconv.FormatUint(v, 10) })
+		require.Equal(t, []KeyValue[uint64, string]{{0, "0"}, {1, "10"}}, iter.KeyValues())
+	})
+
+	t.Run("first and last", func(t *testing.T) {
+		kv, found := all().Skip(3).First()
+		require.True(t, found)
+		require.Equal(t, KeyValue[uint64, uint64]{3, 30}, kv)
+
+		kv, found = all().Filter(even).Last()
+		require.True(t, found)
+		require.Equal(t, KeyValue[uint64, uint64]{8, 80}, kv)
+
+		_, found = all().Skip(10).First()
+		require.False(t, found)
+		_, found = all().Skip(10).Last()
+		require.False(t, found)
+	})
+
+	t.Run("count and any", func(t *testing.T) {
+		require.Equal(t, 10, all().Count())
+		require.Equal(t, 5, all().Filter(even).Count())
+		require.True(t, all().Any(func(_, v uint64) bool { return v == 50 }))
+		require.False(t, all().Any(func(_, v uint64) bool { return v == 55 }))
+	})
+}
+
+func TestIteratorFilterDecodesOnce(t *testing.T) {
+	sk, ctx, _ := deps()
+	decoded := new(int)
+	m := NewMap[uint64, string](sk, 0, Uint64KeyEncoder, countingValueEncoder{stringValue{}, decoded})
+	for i := uint64(0); i < 10; i++ {
+		m.Insert(ctx, i, strconv.FormatUint(i, 10))
+	}
+
+	kvs := m.Iterate(ctx, Range[uint64]{}).
+		Filter(func(_ uint64, v string) bool { return v < "3" }).
+		KeyValues()
+	require.Equal(t, []KeyValue[uint64, string]{{0, "0"}, {1, "1"}, {2, "2"}}, kvs)
+	// every value is decoded once by the predicate, kept ones are not decoded again.
+	require.Equal(t, 10, *decoded)
+
+	// the decoded entries survive the combinators wrapping the filter.
+	*decoded = 0
+	filtered := m.Iterate(ctx, Range[uint64]{}).
+		Filter(func(_ uint64, v string) bool { return v >= "5" }).
+		Skip(1).
+		Take(2)
+	lengths := MapValues(filtered, func(v string) int { return len(v) + 10 }).KeyValues()
+	require.Equal(t, []KeyValue[uint64, int]{{6, 11}, {7, 11}}, lengths)
+	// values 0 to 7 are decoded once by the predicate, and never again.
+	require.Equal(t, 8, *decoded)
+}
+
+func TestKeySetIteratorCombinators(t *testing.T) {
+	sk, ctx, _ := deps()
+	ks := NewKeySet[uint64](sk, 0, Uint64KeyEncoder)
+	ks.InsertMany(ctx, []uint64{1, 2, 3, 4, 5})
+	all := func() KeySetIterator[uint64] { return ks.Iterate(ctx, Range[uint64]{}) }
+
+	require.Equal(t, []uint64{2, 4}, all().Filter(func(k uint64) bool { return k%2 == 0 }).Keys())
+	require.Equal(t, []uint64{2, 3}, all().Skip(1).Take(2).Keys())
+	first, found := all().First()
+	require.True(t, found)
+	require.Equal(t, uint64(1), first)
+	last, found := all().Last()
+	require.True(t, found)
+	require.Equal(t, uint64(5), last)
+	require.Equal(t, 5, all().Count())
+	require.True(t, all().Any(func(k uint64) bool { return k == 3 }))
+}
+
+func TestIndexerIteratorCombinators(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewIndexedMap[uint64, person, indexes](
+		sk, 0,
+		Uint64KeyEncoder, jsonValue[person]{},
+		indexes{
+			City: NewMultiIndex[string, uint64, person](sk, 1,
+				StringKeyEncoder, Uint64KeyEncoder,
+				func(v person) string {
+					return v.City
+				}),
+		},
+	)
+	for i := uint64(0); i < 6; i++ {
+		city := "milan"
+		if i%3 == 0 {
+			city = "rome"
+		}
+		m.Insert(ctx, i, person{ID: i, City: city})
+	}
+
+	milan := func() IndexerIterator[string, uint64] { return m.Indexes.City.ExactMatch(ctx, "milan") }
+	require.Equal(t, []uint64{1, 2, 4, 5}, milan().PrimaryKeys())
+	require.Equal(t, []uint64{2, 4}, milan().Skip(1).Take(2).PrimaryKeys())
+	require.Equal(t, []uint64{4, 5}, milan().Filter(func(k Pair[string, uint64]) bool { return k.K2() > 3 }).PrimaryKeys())
+	require.Equal(t, 4, milan().Count())
+	last, found := milan().Last()
+	require.True(t, found)
+	require.Equal(t, Join("milan", uint64(5)), last)
+	first, found := m.Indexes.City.ExactMatch(ctx, "rome").First()
+	require.True(t, found)
+	require.Equal(t, Join("rome", uint64(0)), first)
+	require.False(t, milan().Any(func(k Pair[string, uint64]) bool { return k.K2() == 3 }))
+}
@@ -0,0 +1,386 @@
+package collections
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	storetypes "cosmossdk.io/store/types"
+)
+
+// KeyStream is implemented by Iterator and KeySetIterator. It is used
+// to combine iterators which share the same KeyEncoder, by comparing
+// their encoded keys in a single pass.
+type KeyStream[K any] interface {
+	Valid() bool
+	Next()
+	Key() K
+	Close()
+
+	// storeIterator returns the underlying storetypes.Iterator,
+	// the prefix of its keys and its order.
+	storeIterator() (storetypes.Iterator, []byte, Order)
+	// keyEncoder returns the KeyEncoder of the keys.
+	keyEncoder() KeyEncoder[K]
+}
+
+func (i Iterator[K, V]) storeIterator() (storetypes.Iterator, []byte, Order) {
+	return i.iter, i.prefixBytes, i.order
+}
+
+func (i Iterator[K, V]) keyEncoder() KeyEncoder[K] { return i.kc }
+
+func (s KeySetIterator[K]) storeIterator() (storetypes.Iterator, []byte, Order) {
+	return (Iterator[K, setObject])(s).storeIterator()
+}
+
+func (s KeySetIterator[K]) keyEncoder() KeyEncoder[K] { return s.kc }
+
+// Merge returns an Iterator which provides the key-value pairs of both i and other,
+// ordered by key. If a key is present in both, the pair of i is provided first.
+// Both iterators must use the same KeyEncoder and order, and they are closed together.
+// Iterators whose KeyEncoders have different types cause a panic, while iterators
+// using different instances of the same KeyEncoder type produce undefined results.
+func (i Iterator[K, V]) Merge(other Iterator[K, V]) Iterator[K, V] {
+	return i.combine(other, func(a, b storetypes.Iterator, aPrefix, bPrefix []byte) storetypes.Iterator {
+		return newMergeIterator(a, b, aPrefix, bPrefix, i.order, false)
+	})
+}
+
+// Intersect returns an Iterator which provides only the key-value pairs of i whose key is
+// also provided by other. Both iterators must use the same KeyEncoder and order, and they
+// are closed together. See Merge for iterators using different KeyEncoders.
+func (i Iterator[K, V]) Intersect(other KeyStream[K]) Iterator[K, V] {
+	return i.combine(other, func(a, b storetypes.Iterator, aPrefix, bPrefix []byte) storetypes.Iterator {
+		return newSeekIterator(a, b, aPrefix, bPrefix, i.order, true)
+	})
+}
+
+// Difference returns an Iterator which provides only the key-value pairs of i whose key is
+// not provided by other. Both iterators must use the same KeyEncoder and order, and they
+// are closed together. See Merge for iterators using different KeyEncoders.
+func (i Iterator[K, V]) Difference(other KeyStream[K]) Iterator[K, V] {
+	return i.combine(other, func(a, b storetypes.Iterator, aPrefix, bPrefix []byte) storetypes.Iterator {
+		return newSeekIterator(a, b, aPrefix, bPrefix, i.order, false)
+	})
+}
+
+// combine creates an Iterator over the storetypes.Iterator created by combining
+// the storetypes.Iterator of i and the other KeyStream. The keys of the combined
+// storetypes.Iterator are relative to the common prefix of the two iterators.
+func (i Iterator[K, V]) combine(
+	other KeyStream[K],
+	combine func(a, b storetypes.Iterator, aPrefix, bPrefix []byte) storetypes.Iterator,
+) Iterator[K, V] {
+	b, bPrefix, bOrder := other.storeIterator()
+	checkCombinable(i.kc, other.keyEncoder(), i.order, bOrder)
+	common := commonPrefix(i.prefixBytes, bPrefix)
+	return Iterator[K, V]{
+		kc:          i.kc,
+		vc:          i.vc,
+		iter:        combine(i.iter, b, i.prefixBytes[len(common):], bPrefix[len(common):]),
+		prefixBytes: common,
+		order:       i.order,
+		scratch:     i.scratch,
+	}
+}
+
+// checkCombinable panics if two iterators cannot be combined.
+func checkCombinable[K any](a, b KeyEncoder[K], aOrder, bOrder Order) {
+	if aOrder != bOrder {
+		panic(fmt.Errorf("collections: cannot combine iterators with different orders: %v and %v", aOrder, bOrder))
+	}
+	if aType, bType := fmt.Sprintf("%T", a), fmt.Sprintf("%T", b); aType != bType {
+		panic(fmt.Errorf("collections: cannot combine iterators with different KeyEncoders: %s and %s", aType, bType))
+	}
+}
+
+// commonPrefix returns the longest common prefix of a and b.
+func commonPrefix(a, b []byte) []byte {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return a[:n]
+}
+
+// Union returns a KeySetIterator which provides the keys of both s and other, ordered,
+// and without duplicates. Both iterators must use the same KeyEncoder and order,
+// and they are closed together.
+func (s KeySetIterator[K]) Union(other KeySetIterator[K]) KeySetIterator[K] {
+	i := (Iterator[K, setObject])(s)
+	return (KeySetIterator[K])(i.combine(other, func(a, b storetypes.Iterator, aPrefix, bPrefix []byte) storetypes.Iterator {
+		return newMergeIterator(a, b, aPrefix, bPrefix, i.order, true)
+	}))
+}
+
+// Intersect returns a KeySetIterator which provides only the keys of s which
+// are also provided by other. See Iterator.Intersect.
+func (s KeySetIterator[K]) Intersect(other KeyStream[K]) KeySetIterator[K] {
+	return (KeySetIterator[K])((Iterator[K, setObject])(s).Intersect(other))
+}
+
+// Difference returns a KeySetIterator which provides only the keys of s which
+// are not provided by other. See Iterator.Difference.
+func (s KeySetIterator[K]) Difference(other KeyStream[K]) KeySetIterator[K] {
+	return (KeySetIterator[K])((Iterator[K, setObject])(s).Difference(other))
+}
+
+// Joined is the value provided by a LeftJoin Iterator.
+type Joined[L, R any] struct {
+	// Left is the value of the left Iterator.
+	Left L
+	// Right is the value of the right Iterator, it is
+	// the zero value of R if HasRight is false.
+	Right R
+	// HasRight reports whether the right Iterator
+	// provided a value for the key.
+	HasRight bool
+}
+
+// LeftJoin returns an Iterator which provides all the keys of left, joined with the
+// values of right associated with the same keys, if any. Both iterators must use the
+// same KeyEncoder and order, and they are closed together. See Iterator.Merge for
+// iterators using different KeyEncoders.
+func LeftJoin[K, L, R any](left Iterator[K, L], right Iterator[K, R]) Iterator[K, Joined[L, R]] {
+	checkCombinable(left.kc, right.kc, left.order, right.order)
+	common := commonPrefix(left.prefixBytes, right.prefixBytes)
+	return Iterator[K, Joined[L, R]]{
+		kc: left.kc,
+		vc: joinedValueEncoder[L, R]{left: left.vc, right: right.vc},
+		iter: &leftJoinIterator{
+			pairIterator: pairIterator{
+				a: left.iter, b: right.iter,
+				aPrefix: left.prefixBytes[len(common):], bPrefix: right.prefixBytes[len(common):],
+				order: left.order,
+			},
+		},
+		prefixBytes: common,
+		order:       left.order,
+		scratch:     left.scratch,
+	}
+}
+
+// keyOrder provides the position of the encoded keys of two iterators,
+// prefixed by aPrefix and bPrefix, in the given Order.
+func keyOrder(order Order, aPrefix, a, bPrefix, b []byte) int {
+	c := compareJoined(aPrefix, a, bPrefix, b)
+	if order == OrderDescending {
+		return -c
+	}
+	return c
+}
+
+// compareJoined compares aPrefix|a with bPrefix|b, without joining them.
+func compareJoined(aPrefix, a, bPrefix, b []byte) int {
+	for {
+		if len(aPrefix) == 0 {
+			aPrefix, a = a, nil
+		}
+		if len(bPrefix) == 0 {
+			bPrefix, b = b, nil
+		}
+		switch {
+		case len(aPrefix) == 0 && len(bPrefix) == 0:
+			return 0
+		case len(aPrefix) == 0:
+			return -1
+		case len(bPrefix) == 0:
+			return 1
+		}
+		n := min(len(aPrefix), len(bPrefix))
+		if c := bytes.Compare(aPrefix[:n], bPrefix[:n]); c != 0 {
+			return c
+		}
+		aPrefix, bPrefix = aPrefix[n:], bPrefix[n:]
+	}
+}
+
+// joinKey returns key prefixed with prefix, it
+// allocates only if the prefix is not empty.
+func joinKey(prefix, key []byte) []byte {
+	if len(prefix) == 0 {
+		return key
+	}
+	rawKey := make([]byte, 0, len(prefix)+len(key))
+	return append(append(rawKey, prefix...), key...)
+}
+
+// pairIterator holds two storetypes.Iterator which are combined together.
+type pairIterator struct {
+	a, b             storetypes.Iterator
+	aPrefix, bPrefix []byte
+	order            Order
+}
+
+func (p pairIterator) Domain() (start, end []byte) { return nil, nil }
+func (p pairIterator) Error() error                { return errors.Join(p.a.Error(), p.b.Error()) }
+func (p pairIterator) Close() error                { return errors.Join(p.a.Close(), p.b.Close()) }
+
+// seek moves b onto the first key which is not before the current key of a,
+// and reports whether the keys are equal.
+func (p pairIterator) seek() bool {
+	for p.b.Valid() {
+		c := keyOrder(p.order, p.bPrefix, p.b.Key(), p.aPrefix, p.a.Key())
+		if c >= 0 {
+			return c == 0
+		}
+		p.b.Next()
+	}
+	return false
+}
+
+// mergeIterator provides the entries of both a and b, ordered by key.
+// The entries of a are provided first on equal keys, if dedup is
+// set the entries of b are skipped on equal keys instead.
+type mergeIterator struct {
+	pairIterator
+	dedup bool
+	// current is the iterator providing the current entry.
+	current storetypes.Iterator
+	prefix  []byte
+}
+
+func newMergeIterator(a, b storetypes.Iterator, aPrefix, bPrefix []byte, order Order, dedup bool) *mergeIterator {
+	m := &mergeIterator{
+		pairIterator: pairIterator{a: a, b: b, aPrefix: aPrefix, bPrefix: bPrefix, order: order},
+		dedup:        dedup,
+	}
+	m.pick()
+	return m
+}
+
+// pick selects the iterator providing the next entry.
+func (m *mergeIterator) pick() {
+	aValid, bValid := m.a.Valid(), m.b.Valid()
+	switch {
+	case !aValid && !bValid:
+		m.current, m.prefix = nil, nil
+	case !bValid:
+		m.current, m.prefix = m.a, m.aPrefix
+	case !aValid:
+		m.current, m.prefix = m.b, m.bPrefix
+	default:
+		c := keyOrder(m.order, m.aPrefix, m.a.Key(), m.bPrefix, m.b.Key())
+		if c == 0 && m.dedup {
+			m.b.Next()
+		}
+		if c <= 0 {
+			m.current, m.prefix = m.a, m.aPrefix
+		} else {
+			m.current, m.prefix = m.b, m.bPrefix
+		}
+	}
+}
+
+func (m *mergeIterator) Valid() bool   { return m.current != nil }
+func (m *mergeIterator) Key() []byte   { return joinKey(m.prefix, m.current.Key()) }
+func (m *mergeIterator) Value() []byte { return m.current.Value() }
+
+func (m *mergeIterator) Next() {
+	m.current.Next()
+	m.pick()
+}
+
+// seekIterator provides the entries of a, moving b onto their keys.
+// If intersect is set only the entries of a whose key is provided by b
+// are provided, otherwise only the ones whose key is not provided by b.
+type seekIterator struct {
+	pairIterator
+	intersect bool
+}
+
+func newSeekIterator(a, b storetypes.Iterator, aPrefix, bPrefix []byte, order Order, intersect bool) *seekIterator {
+	s := &seekIterator{
+		pairIterator: pairIterator{a: a, b: b, aPrefix: aPrefix, bPrefix: bPrefix, order: order},
+		intersect:    intersect,
+	}
+	s.skip()
+	return s
+}
+
+// skip moves a onto the next entry to provide.
+func (s *seekIterator) skip() {
+	for s.a.Valid() && s.seek() != s.intersect {
+		s.a.Next()
+	}
+}
+
+func (s *seekIterator) Valid() bool   { return s.a.Valid() }
+func (s *seekIterator) Key() []byte   { return joinKey(s.aPrefix, s.a.Key()) }
+func (s *seekIterator) Value() []byte { return s.a.Value() }
+
+func (s *seekIterator) Next() {
+	s.a.Next()
+	s.skip()
+}
+
+// leftJoinIterator provides the entries of a, with values
+// joined to the values of b for the same key, if any.
+// Joined values are encoded as:
+// | has right (1 byte) | left length (uvarint) | left | right |
+type leftJoinIterator struct {
+	pairIterator
+}
+
+func (l *leftJoinIterator) Valid() bool { return l.a.Valid() }
+func (l *leftJoinIterator) Next()       { l.a.Next() }
+func (l *leftJoinIterator) Key() []byte { return joinKey(l.aPrefix, l.a.Key()) }
+
+func (l *leftJoinIterator) Value() []byte {
+	left := l.a.Value()
+	var right []byte
+	hasRight := l.seek()
+	if hasRight {
+		right = l.b.Value()
+	}
+	b := make([]byte, 0, 1+binary.MaxVarintLen64+len(left)+len(right))
+	if hasRight {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	b = binary.AppendUvarint(b, uint64(len(left)))
+	b = append(b, left...)
+	return append(b, right...)
+}
+
+// joinedValueEncoder is a decode only ValueEncoder
+// of the values provided by leftJoinIterator.
+type joinedValueEncoder[L, R any] struct {
+	left  ValueEncoder[L]
+	right ValueEncoder[R]
+}
+
+func (j joinedValueEncoder[L, R]) Encode(Joined[L, R]) []byte {
+	panic("collections: joined values cannot be encoded")
+}
+
+func (j joinedValueEncoder[L, R]) Decode(b []byte) (v Joined[L, R]) {
+	if len(b) == 0 {
+		panic(fmt.Sprintf("invalid joined value bytes: %s", HumanizeBytes(b)))
+	}
+	v.HasRight = b[0] == 1
+	leftLen, n := binary.Uvarint(b[1:])
+	if n <= 0 || uint64(len(b)-1-n) < leftLen {
+		panic(fmt.Sprintf("invalid joined value bytes: %s", HumanizeBytes(b)))
+	}
+	rest := b[1+n:]
+	v.Left = j.left.Decode(rest[:leftLen])
+	if v.HasRight {
+		v.Right = j.right.Decode(rest[leftLen:])
+	}
+	return v
+}
+
+func (j joinedValueEncoder[L, R]) Stringify(v Joined[L, R]) string {
+	if !v.HasRight {
+		return fmt.Sprintf("Joined{%s, <nil>}", j.left.Stringify(v.Left))
+	}
+	return fmt.Sprintf("Joined{%s, %s}", j.left.Stringify(v.Left), j.right.Stringify(v.Right))
+}
+
+func (j joinedValueEncoder[L, R]) Name() string {
+	return fmt.Sprintf("Joined[%s, %s]", j.left.Name(), j.right.Name())
+}
@@ -0,0 +1,125 @@
+package collections
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIteratorMerge(t *testing.T) {
+	sk, ctx, _ := deps()
+	long := NewMap[uint64, string](sk, 0, Uint64KeyEncoder, stringValue{})
+	short := NewMap[uint64, string](sk, 1, Uint64KeyEncoder, stringValue{})
+	long.InsertMany(ctx, []KeyValue[uint64, string]{{1, "long-1"}, {3, "long-3"}, {4, "long-4"}})
+	short.InsertMany(ctx, []KeyValue[uint64, string]{{0, "short-0"}, {3, "short-3"}, {5, "short-5"}})
+
+	kvs := long.Iterate(ctx, Range[uint64]{}).Merge(short.Iterate(ctx, Range[uint64]{})).KeyValues()
+	require.Equal(t, []KeyValue[uint64, string]{
+		{0, "short-0"}, {1, "long-1"}, {3, "long-3"}, {3, "short-3"}, {4, "long-4"}, {5, "short-5"},
+	}, kvs)
+
+	values := long.Iterate(ctx, Range[uint64]{}.Descending()).
+		Merge(short.Iterate(ctx, Range[uint64]{}.Descending())).Values()
+	require.Equal(t, []string{"short-5", "long-4", "long-3", "short-3", "long-1", "short-0"}, values)
+
+	require.Panics(t, func() {
+		long.Iterate(ctx, Range[uint64]{}).Merge(short.Iterate(ctx, Range[uint64]{}.Descending()))
+	})
+	// iterators with KeyEncoders of different types cannot be combined
+	other := NewMap[uint64, string](sk, 2, ReverseKeyEncoder(Uint64KeyEncoder), stringValue{})
+	require.Panics(t, func() {
+		long.Iterate(ctx, Range[uint64]{}).Merge(other.Iterate(ctx, Range[uint64]{}))
+	})
+}
+
+func TestCompareJoined(t *testing.T) {
+	parts := [][]byte{nil, {0x00}, {0x01}, {0x01, 0x00}, {0x01, 0x02}, {0xFF}, {0x01, 0x02, 0x03}}
+	for _, aPrefix := range parts {
+		for _, a := range parts {
+			for _, bPrefix := range parts {
+				for _, b := range parts {
+					expected := bytes.Compare(append(bytes.Clone(aPrefix), a...), append(bytes.Clone(bPrefix), b...))
+					require.Equal(t, expected, compareJoined(aPrefix, a, bPrefix, b), "%x|%x %x|%x", aPrefix, a, bPrefix, b)
+				}
+			}
+		}
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		keyOrder(OrderDescending, []byte{0x01}, []byte{0x02, 0x03}, []byte{0x01, 0x02}, []byte{0x04})
+	})
+	require.Zero(t, allocs)
+}
+
+func TestIteratorIntersectDifference(t *testing.T) {
+	sk, ctx, _ := deps()
+	kc := PairKeyEncoder(StringKeyEncoder, Uint64KeyEncoder)
+	m := NewMap[Pair[string, uint64], uint64](sk, 0, kc, uint64Value{})
+	ks := NewKeySet[Pair[string, uint64]](sk, 1, kc)
+	for i := uint64(0); i < 6; i++ {
+		m.Insert(ctx, Join("a", i), i)
+		m.Insert(ctx, Join("b", i), i)
+	}
+	ks.InsertMany(ctx, []Pair[string, uint64]{Join("a", uint64(1)), Join("a", uint64(4)), Join("b", uint64(2)), Join("c", uint64(0))})
+
+	all := Range[Pair[string, uint64]]{}
+	require.Equal(t,
+		[]Pair[string, uint64]{Join("a", uint64(1)), Join("a", uint64(4)), Join("b", uint64(2))},
+		m.Iterate(ctx, all).Intersect(ks.Iterate(ctx, all)).Keys(),
+	)
+	// iterators with different range prefixes are compared on the whole key
+	require.Equal(t,
+		[]uint64{1, 4},
+		m.Iterate(ctx, PairRange[string, uint64]{}.Prefix("a")).Intersect(ks.Iterate(ctx, all)).Values(),
+	)
+	require.Equal(t,
+		[]uint64{0, 2, 3, 5},
+		m.Iterate(ctx, PairRange[string, uint64]{}.Prefix("a")).Difference(ks.Iterate(ctx, all)).Values(),
+	)
+	require.Equal(t,
+		[]uint64{5, 3, 2, 0},
+		m.Iterate(ctx, PairRange[string, uint64]{}.Prefix("a").Descending()).
+			Difference(ks.Iterate(ctx, all.Descending())).Values(),
+	)
+	require.Equal(t,
+		[]Pair[string, uint64]{Join("c", uint64(0))},
+		ks.Iterate(ctx, all).Difference(m.Iterate(ctx, all)).Keys(),
+	)
+}
+
+func TestKeySetIteratorUnion(t *testing.T) {
+	sk, ctx, _ := deps()
+	a := NewKeySet[uint64](sk, 0, Uint64KeyEncoder)
+	b := NewKeySet[uint64](sk, 1, Uint64KeyEncoder)
+	a.InsertMany(ctx, []uint64{1, 2, 5})
+	b.InsertMany(ctx, []uint64{0, 2, 3, 5, 7})
+
+	require.Equal(t, []uint64{0, 1, 2, 3, 5, 7}, a.Iterate(ctx, Range[uint64]{}).Union(b.Iterate(ctx, Range[uint64]{})).Keys())
+	require.Equal(t, []uint64{2, 5}, a.Iterate(ctx, Range[uint64]{}).Intersect(b.Iterate(ctx, Range[uint64]{})).Keys())
+	require.Equal(t, []uint64{1}, a.Iterate(ctx, Range[uint64]{}).Difference(b.Iterate(ctx, Range[uint64]{})).Keys())
+	// combined iterators compose with the other combinators
+	require.Equal(t, 3, a.Iterate(ctx, Range[uint64]{}).Union(b.Iterate(ctx, Range[uint64]{})).Skip(3).Count())
+}
+
+func TestLeftJoin(t *testing.T) {
+	sk, ctx, _ := deps()
+	positions := NewMap[string, uint64](sk, 0, StringKeyEncoder, uint64Value{})
+	prices := NewMap[string, string](sk, 1, StringKeyEncoder, stringValue{})
+	positions.InsertMany(ctx, []KeyValue[string, uint64]{{"atom", 10}, {"btc", 1}, {"eth", 5}})
+	prices.InsertMany(ctx, []KeyValue[string, string]{{"aaa", "0"}, {"atom", "9"}, {"eth", "3000"}, {"zzz", "1"}})
+
+	kvs := LeftJoin(positions.Iterate(ctx, Range[string]{}), prices.Iterate(ctx, Range[string]{})).KeyValues()
+	require.Equal(t, []KeyValue[string, Joined[uint64, string]]{
+		{"atom", Joined[uint64, string]{Left: 10, Right: "9", HasRight: true}},
+		{"btc", Joined[uint64, string]{Left: 1}},
+		{"eth", Joined[uint64, string]{Left: 5, Right: "3000", HasRight: true}},
+	}, kvs)
+
+	// values are joined lazily, keys only iteration does not touch the right iterator values
+	keys := LeftJoin(positions.Iterate(ctx, Range[string]{}.Descending()), prices.Iterate(ctx, Range[string]{}.Descending())).Keys()
+	require.Equal(t, []string{"eth", "btc", "atom"}, keys)
+
+	last, found := LeftJoin(positions.Iterate(ctx, Range[string]{}), prices.Iterate(ctx, Range[string]{})).Last()
+	require.True(t, found)
+	require.Equal(t, Joined[uint64, string]{Left: 5, Right: "3000", HasRight: true}, last.Value)
+}
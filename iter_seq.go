@@ -0,0 +1,110 @@
+//go:build go1.23
+
+package collections
+
+import (
+	"context"
+	"iter"
+)
+
+// All returns an iter.Seq2 over the keys and values of the iterator.
+// The iterator is fully consumed and it is closed once the iteration
+// ends, even if the loop is exited early.
+//
+// The store iterator is already open, so the returned sequence can be ranged
+// over only once, and it must be ranged over or the iterator is never closed.
+// Map.All opens the iterator only when the sequence is ranged over.
+func (i Iterator[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		defer i.Close()
+		for ; i.iter.Valid(); i.iter.Next() {
+			if !yield(i.Key(), i.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// AllKeys returns an iter.Seq over the keys of the iterator, values are not decoded.
+// The iterator is closed once the iteration ends, even if the loop is exited early.
+// Like All, the returned sequence must be ranged over exactly once.
+func (i Iterator[K, V]) AllKeys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		defer i.Close()
+		for ; i.iter.Valid(); i.iter.Next() {
+			if !yield(i.Key()) {
+				return
+			}
+		}
+	}
+}
+
+// AllValues returns an iter.Seq over the values of the iterator, keys are not decoded.
+// The iterator is closed once the iteration ends, even if the loop is exited early.
+// Like All, the returned sequence must be ranged over exactly once.
+func (i Iterator[K, V]) AllValues() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		defer i.Close()
+		for ; i.iter.Valid(); i.iter.Next() {
+			if !yield(i.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iter.Seq over the keys of the KeySetIterator.
+// The iterator is closed once the iteration ends, even if the loop is exited early.
+// Like Iterator.All, the returned sequence must be ranged over exactly once.
+func (s KeySetIterator[K]) All() iter.Seq[K] { return (Iterator[K, setObject])(s).AllKeys() }
+
+// All returns an iter.Seq over the joint indexing and primary keys of the IndexerIterator.
+// The iterator is closed once the iteration ends, even if the loop is exited early.
+// Like Iterator.All, the returned sequence must be ranged over exactly once.
+func (i IndexerIterator[IK, PK]) All() iter.Seq[Pair[IK, PK]] {
+	return (KeySetIterator[Pair[IK, PK]])(i).All()
+}
+
+// AllPrimaryKeys returns an iter.Seq over the primary keys of the IndexerIterator.
+// The iterator is closed once the iteration ends, even if the loop is exited early.
+// Like Iterator.All, the returned sequence must be ranged over exactly once.
+func (i IndexerIterator[IK, PK]) AllPrimaryKeys() iter.Seq[PK] {
+	return func(yield func(PK) bool) {
+		for k := range i.All() {
+			if !yield(k.K2()) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iter.Seq2 over the keys and values of the map within the provided
+// range, a nil Ranger iterates over the whole map. The store iterator is opened
+// every time the sequence is ranged over, and closed once the loop ends.
+func (m Map[K, V]) All(ctx context.Context, rng Ranger[K]) iter.Seq2[K, V] {
+	if rng == nil {
+		rng = Range[K]{}
+	}
+	return func(yield func(K, V) bool) {
+		m.Iterate(ctx, rng).All()(yield)
+	}
+}
+
+// All returns an iter.Seq over the keys of the set within the provided range,
+// a nil Ranger iterates over the whole set. The store iterator is opened
+// every time the sequence is ranged over, and closed once the loop ends.
+func (s KeySet[K]) All(ctx context.Context, rng Ranger[K]) iter.Seq[K] {
+	if rng == nil {
+		rng = Range[K]{}
+	}
+	return func(yield func(K) bool) {
+		s.Iterate(ctx, rng).All()(yield)
+	}
+}
+
+// All returns an iter.Seq2 over the primary keys and objects of the IndexedMap within
+// the provided range, a nil Ranger iterates over the whole IndexedMap. The store iterator
+// is opened every time the sequence is ranged over, and closed once the loop ends.
+func (i IndexedMap[PK, V, I]) All(ctx context.Context, rng Ranger[PK]) iter.Seq2[PK, V] {
+	return i.m.All(ctx, rng)
+}
@@ -0,0 +1,143 @@
+//go:build go1.23
+
+package collections
+
+import (
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/stretchr/testify/require"
+)
+
+// closeTracker records whether the wrapped storetypes.Iterator was closed.
+type closeTracker struct {
+	storetypes.Iterator
+	closed *bool
+}
+
+func (c closeTracker) Close() error {
+	*c.closed = true
+	return c.Iterator.Close()
+}
+
+func TestIteratorSeq(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewMap[uint64, uint64](sk, 0, Uint64KeyEncoder, uint64Value{})
+	for i := uint64(0); i < 5; i++ {
+		m.Insert(ctx, i, i*10)
+	}
+	tracked := func() (Iterator[uint64, uint64], *bool) {
+		iter := m.Iterate(ctx, Range[uint64]{})
+		closed := new(bool)
+		iter.iter = closeTracker{iter.iter, closed}
+		return iter, closed
+	}
+
+	t.Run("all", func(t *testing.T) {
+		iter, closed := tracked()
+		var kvs []KeyValue[uint64, uint64]
+		for k, v := range iter.All() {
+			kvs = append(kvs, KeyValue[uint64, uint64]{k, v})
+		}
+		require.Len(t, kvs, 5)
+		require.Equal(t, KeyValue[uint64, uint64]{2, 20}, kvs[2])
+		require.True(t, *closed)
+	})
+
+	t.Run("break closes the iterator", func(t *testing.T) {
+		iter, closed := tracked()
+		var keys []uint64
+		for k := range iter.AllKeys() {
+			if k == 2 {
+				break
+			}
+			keys = append(keys, k)
+		}
+		require.Equal(t, []uint64{0, 1}, keys)
+		require.True(t, *closed)
+	})
+
+	t.Run("values", func(t *testing.T) {
+		iter, closed := tracked()
+		var values []uint64
+		for v := range iter.AllValues() {
+			values = append(values, v)
+		}
+		require.Equal(t, []uint64{0, 10, 20, 30, 40}, values)
+		require.True(t, *closed)
+	})
+}
+
+func TestKeySetAndIndexerSeq(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewIndexedMap[uint64, person, indexes](
+		sk, 0,
+		Uint64KeyEncoder, jsonValue[person]{},
+		indexes{
+			City: NewMultiIndex[string, uint64, person](sk, 1,
+				StringKeyEncoder, Uint64KeyEncoder,
+				func(v person) string {
+					return v.City
+				}),
+		},
+	)
+	m.Insert(ctx, 0, person{ID: 0, City: "milan"})
+	m.Insert(ctx, 1, person{ID: 1, City: "new york"})
+	m.Insert(ctx, 2, person{ID: 2, City: "milan"})
+
+	var pks []uint64
+	for pk := range m.Indexes.City.ExactMatch(ctx, "milan").AllPrimaryKeys() {
+		pks = append(pks, pk)
+	}
+	require.Equal(t, []uint64{0, 2}, pks)
+
+	var full []Pair[string, uint64]
+	for k := range m.Indexes.City.Iterate(ctx, Range[Pair[string, uint64]]{}).All() {
+		full = append(full, k)
+		break
+	}
+	require.Equal(t, []Pair[string, uint64]{Join("milan", uint64(0))}, full)
+
+	ks := NewKeySet[string](sk, 2, StringKeyEncoder)
+	ks.InsertMany(ctx, []string{"a", "b"})
+	var keys []string
+	for k := range ks.Iterate(ctx, Range[string]{}).All() {
+		keys = append(keys, k)
+	}
+	require.Equal(t, []string{"a", "b"}, keys)
+}
+
+func TestCollectionSeq(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewMap[uint64, uint64](sk, 0, Uint64KeyEncoder, uint64Value{})
+	for i := uint64(0); i < 5; i++ {
+		m.Insert(ctx, i, i*10)
+	}
+
+	// the sequence opens a new iterator every time it is ranged over
+	seq := m.All(ctx, Range[uint64]{}.StartInclusive(3))
+	for range 2 {
+		var kvs []KeyValue[uint64, uint64]
+		for k, v := range seq {
+			kvs = append(kvs, KeyValue[uint64, uint64]{k, v})
+		}
+		require.Equal(t, []KeyValue[uint64, uint64]{{3, 30}, {4, 40}}, kvs)
+	}
+
+	var keys []uint64
+	for k := range m.All(ctx, nil) {
+		if k == 2 {
+			break
+		}
+		keys = append(keys, k)
+	}
+	require.Equal(t, []uint64{0, 1}, keys)
+
+	ks := NewKeySet[string](sk, 1, StringKeyEncoder)
+	ks.InsertMany(ctx, []string{"a", "b", "c"})
+	var set []string
+	for k := range ks.All(ctx, Range[string]{}.Descending()) {
+		set = append(set, k)
+	}
+	require.Equal(t, []string{"c", "b", "a"}, set)
+}
@@ -2,6 +2,9 @@ package collections
 
 import (
 	"testing"
+	"time"
+
+	"cosmossdk.io/math"
 
 	"github.com/stretchr/testify/require"
 )
@@ -30,3 +33,192 @@ func TestRangeBounds(t *testing.T) {
 	result = ks.Iterate(ctx, Range[uint64]{}.StartInclusive(1).EndExclusive(5).Descending()).Keys()
 	require.Equal(t, []uint64{4, 3, 2, 1}, result)
 }
+
+func TestRangeBoundsSigned(t *testing.T) {
+	sk, ctx, _ := deps()
+
+	ks := NewKeySet[int64](sk, 0, Int64KeyEncoder)
+	for _, k := range []int64{-3, -2, -1, 0, 1, 2, 3} {
+		ks.Insert(ctx, k)
+	}
+
+	// let's range [-2, 2); expected: -2..1
+	result := ks.Iterate(ctx, Range[int64]{}.StartInclusive(-2).EndExclusive(2)).Keys()
+	require.Equal(t, []int64{-2, -1, 0, 1}, result)
+
+	// let's range (-3, 0]; expected: -2..0
+	result = ks.Iterate(ctx, Range[int64]{}.StartExclusive(-3).EndInclusive(0)).Keys()
+	require.Equal(t, []int64{-2, -1, 0}, result)
+
+	// let's range [-1, 3) descending; expected: 2..-1
+	result = ks.Iterate(ctx, Range[int64]{}.StartInclusive(-1).EndExclusive(3).Descending()).Keys()
+	require.Equal(t, []int64{2, 1, 0, -1}, result)
+}
+
+func TestRangeBoundsDec(t *testing.T) {
+	sk, ctx, _ := deps()
+
+	ks := NewKeySet[Pair[math.LegacyDec, string]](sk, 0, PairKeyEncoder(DecKeyEncoder, StringKeyEncoder))
+	keys := []Pair[math.LegacyDec, string]{
+		Join(math.LegacyMustNewDecFromStr("-10.5"), "a"),
+		Join(math.LegacyNewDec(-1), "a"),
+		Join(math.LegacyNewDec(-1), "b"),
+		Join(math.LegacyZeroDec(), "a"),
+		Join(math.LegacyMustNewDecFromStr("0.25"), "a"),
+		Join(math.LegacyNewDec(100), "a"),
+	}
+	// insert in reverse to make sure the ordering is given by the encoding.
+	for i := len(keys) - 1; i >= 0; i-- {
+		ks.Insert(ctx, keys[i])
+	}
+
+	result := ks.Iterate(ctx, Range[Pair[math.LegacyDec, string]]{}).Keys()
+	require.Equal(t, keys, result)
+
+	result = ks.Iterate(ctx, PairRange[math.LegacyDec, string]{}.Prefix(math.LegacyNewDec(-1))).Keys()
+	require.Equal(t, keys[1:3], result)
+
+	result = ks.Iterate(ctx, Range[Pair[math.LegacyDec, string]]{}.
+		StartInclusive(PairPrefix[math.LegacyDec, string](math.LegacyNewDec(-1))).
+		EndExclusive(PairPrefix[math.LegacyDec, string](math.LegacyNewDec(100))).
+		Descending()).Keys()
+	require.Equal(t, []Pair[math.LegacyDec, string]{keys[4], keys[3], keys[2], keys[1]}, result)
+}
+
+func TestRangeBoundsTimePrefix(t *testing.T) {
+	sk, ctx, _ := deps()
+
+	// an expiry queue keyed by expiry time and id.
+	ks := NewKeySet[Pair[time.Time, uint64]](sk, 0, PairKeyEncoder(TimeUnixNanoKeyEncoder, Uint64KeyEncoder))
+	keys := []Pair[time.Time, uint64]{
+		Join(time.Unix(-100, 0).UTC(), uint64(2)),
+		Join(time.Unix(0, 0).UTC(), uint64(1)),
+		Join(time.Unix(0, 0).UTC(), uint64(3)),
+		Join(time.Unix(100, 0).UTC(), uint64(0)),
+	}
+	for _, k := range keys {
+		ks.Insert(ctx, k)
+	}
+
+	// everything expiring before 1970
+	expired := ks.Iterate(ctx, Range[Pair[time.Time, uint64]]{}.
+		EndExclusive(PairPrefix[time.Time, uint64](time.Unix(0, 0).UTC()))).Keys()
+	require.Equal(t, keys[:1], expired)
+
+	// everything expiring before or at 1970
+	expired = ks.Iterate(ctx, Range[Pair[time.Time, uint64]]{}.
+		EndExclusive(PairPrefix[time.Time, uint64](time.Unix(1, 0).UTC()))).Keys()
+	require.Equal(t, keys[:3], expired)
+}
+
+// countingValueEncoder counts the number of decoded values.
+type countingValueEncoder struct {
+	ValueEncoder[string]
+	decoded *int
+}
+
+func (c countingValueEncoder) Decode(b []byte) string {
+	*c.decoded++
+	return c.ValueEncoder.Decode(b)
+}
+
+func TestRawAndLazyIteration(t *testing.T) {
+	sk, ctx, _ := deps()
+	decoded := new(int)
+	m := NewMap[Pair[string, uint64], string](sk, 0,
+		PairKeyEncoder(StringKeyEncoder, Uint64KeyEncoder),
+		countingValueEncoder{stringValue{}, decoded},
+	)
+	for i := uint64(0); i < 3; i++ {
+		m.Insert(ctx, Join("a", i), "value")
+	}
+
+	t.Run("raw key and value", func(t *testing.T) {
+		iter := m.Iterate(ctx, PairRange[string, uint64]{}.Prefix("a"))
+		defer iter.Close()
+		// raw keys are relative to the namespace, hence they include the range prefix
+		require.Equal(t, PairKeyEncoder(StringKeyEncoder, Uint64KeyEncoder).Encode(Join("a", uint64(0))), iter.RawKey())
+		require.Equal(t, stringValue{}.Encode("value"), iter.RawValue())
+		require.Zero(t, *decoded)
+	})
+
+	t.Run("lazy values", func(t *testing.T) {
+		kvs := m.Iterate(ctx, PairRange[string, uint64]{}).LazyKeyValues()
+		require.Len(t, kvs, 3)
+		require.Zero(t, *decoded)
+		require.Equal(t, "value", kvs[1].Value.Value())
+		require.Equal(t, 1, *decoded)
+		v, err := kvs[2].Value.SafeValue()
+		require.NoError(t, err)
+		require.Equal(t, "value", v)
+		require.Equal(t, stringValue{}.Encode("value"), kvs[0].Value.Raw())
+		*decoded = 0
+	})
+
+	t.Run("keys only", func(t *testing.T) {
+		iter := m.IterateKeys(ctx, PairRange[string, uint64]{}.Prefix("a").StartExclusive(0))
+		require.Equal(t, PairKeyEncoder(StringKeyEncoder, Uint64KeyEncoder).Encode(Join("a", uint64(1))), iter.RawKey())
+		require.Equal(t, []Pair[string, uint64]{Join("a", uint64(1)), Join("a", uint64(2))}, iter.Keys())
+		require.Zero(t, *decoded)
+	})
+}
+
+func TestIteratorKeyPrefixAliasing(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewMap[Pair[string, uint64], uint64](sk, 0, PairKeyEncoder(StringKeyEncoder, Uint64KeyEncoder), uint64Value{})
+	m.Insert(ctx, Join("a", uint64(1)), 1)
+	m.Insert(ctx, Join("a", uint64(2)), 2)
+
+	iter := m.Iterate(ctx, PairRange[string, uint64]{}.Prefix("a"))
+	defer iter.Close()
+	// give the prefix spare capacity, which must never be written.
+	prefix := make([]byte, len(iter.prefixBytes), len(iter.prefixBytes)+16)
+	copy(prefix, iter.prefixBytes)
+	spare := prefix[len(prefix):cap(prefix)]
+	for i := range spare {
+		spare[i] = 0xFF
+	}
+	iter.prefixBytes = prefix
+
+	first := iter.Key()
+	iter.Next()
+	second := iter.Key()
+	require.Equal(t, Join("a", uint64(1)), first)
+	require.Equal(t, Join("a", uint64(2)), second)
+	for _, b := range spare {
+		require.Equal(t, byte(0xFF), b)
+	}
+	_, err := iter.SafeKey()
+	require.NoError(t, err)
+	for _, b := range spare {
+		require.Equal(t, byte(0xFF), b)
+	}
+}
+
+// retainingKeyEncoder decodes keys which retain the decoded buffer.
+type retainingKeyEncoder struct{}
+
+func (retainingKeyEncoder) Encode(key []byte) []byte      { return key }
+func (retainingKeyEncoder) Decode(b []byte) (int, []byte) { return len(b), b }
+func (retainingKeyEncoder) Stringify(key []byte) string   { return string(key) }
+
+func TestIteratorRetainingKeyEncoder(t *testing.T) {
+	sk, ctx, _ := deps()
+	kc := PairKeyEncoder(StringKeyEncoder, KeyEncoder[[]byte](retainingKeyEncoder{}))
+	require.False(t, decodeCopies(kc))
+	require.True(t, decodeCopies(PairKeyEncoder(StringKeyEncoder, ReverseKeyEncoder(KeyEncoder[[]byte](retainingKeyEncoder{})))))
+
+	m := NewMap[Pair[string, []byte], uint64](sk, 0, kc, uint64Value{})
+	m.Insert(ctx, Join("a", []byte("x")), 1)
+	m.Insert(ctx, Join("a", []byte("y")), 2)
+
+	// the buffer is not reused, so the keys retaining it are not overwritten.
+	require.Equal(t,
+		[]Pair[string, []byte]{Join("a", []byte("x")), Join("a", []byte("y"))},
+		m.Iterate(ctx, PairRange[string, []byte]{}.Prefix("a")).Keys(),
+	)
+	require.Equal(t,
+		[]Pair[string, []byte]{Join("a", []byte("x")), Join("a", []byte("y"))},
+		m.Iterate(ctx, Range[Pair[string, []byte]]{}).Keys(),
+	)
+}
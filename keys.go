@@ -1,7 +1,10 @@
 package collections
 
 import (
+	"encoding/binary"
 	"fmt"
+	stdmath "math"
+	"math/big"
 	"strconv"
 	"time"
 
@@ -12,18 +15,68 @@ import (
 var (
 	// StringKeyEncoder can be used to encode string keys.
 	StringKeyEncoder KeyEncoder[string] = stringKey{}
+	// TerminalStringKeyEncoder can be used to encode string keys which are not
+	// null terminated. The decoder consumes the whole buffer, so it can only be
+	// used alone or as the last part of a composite key.
+	TerminalStringKeyEncoder KeyEncoder[string] = terminalStringKey{}
 	// AccAddressKeyEncoder can be used to encode sdk.AccAddress keys.
 	AccAddressKeyEncoder KeyEncoder[sdk.AccAddress] = accAddressKey{}
 	// TimeKeyEncoder can be used to encode time.Time keys.
+	// The decoder consumes the whole buffer, so it can only be used
+	// as the last part of a composite key, use TimeUnixNanoKeyEncoder
+	// for the other cases.
 	TimeKeyEncoder KeyEncoder[time.Time] = timeKey{}
+	// TimeUnixNanoKeyEncoder can be used to encode time.Time keys
+	// as fixed length unix nanoseconds. It preserves ordering, including
+	// times before 1970, and only consumes its own bytes, so it can be
+	// used as any part of a composite key. Times are decoded in UTC.
+	TimeUnixNanoKeyEncoder KeyEncoder[time.Time] = timeUnixNanoKey{}
 	// Uint64KeyEncoder can be used to encode uint64 keys.
 	Uint64KeyEncoder KeyEncoder[uint64] = uint64Key{}
+	// Uint32KeyEncoder can be used to encode uint32 keys.
+	Uint32KeyEncoder KeyEncoder[uint32] = uint32Key{}
+	// Uint16KeyEncoder can be used to encode uint16 keys.
+	Uint16KeyEncoder KeyEncoder[uint16] = uint16Key{}
+	// Uint8KeyEncoder can be used to encode uint8 keys.
+	Uint8KeyEncoder KeyEncoder[uint8] = uint8Key{}
+	// BoolKeyEncoder can be used to encode bool keys, false is encoded
+	// as 0x00 and true as 0x01.
+	BoolKeyEncoder KeyEncoder[bool] = boolKey{}
+	// Int64KeyEncoder can be used to encode int64 keys.
+	Int64KeyEncoder KeyEncoder[int64] = int64Key{}
+	// Int32KeyEncoder can be used to encode int32 keys.
+	Int32KeyEncoder KeyEncoder[int32] = int32Key{}
+	// Int16KeyEncoder can be used to encode int16 keys.
+	Int16KeyEncoder KeyEncoder[int16] = int16Key{}
+	// Int8KeyEncoder can be used to encode int8 keys.
+	Int8KeyEncoder KeyEncoder[int8] = int8Key{}
 	// ValAddressKeyEncoder can be used to encode sdk.ValAddress keys.
 	ValAddressKeyEncoder KeyEncoder[sdk.ValAddress] = valAddressKeyEncoder{}
 	// ConsAddressKeyEncoder can be used to encode sdk.ConsAddress keys.
 	ConsAddressKeyEncoder KeyEncoder[sdk.ConsAddress] = consAddressKeyEncoder{}
+	// AccAddressBytesKeyEncoder can be used to encode sdk.AccAddress keys as
+	// length prefixed raw bytes, it does not depend on the bech32 prefix
+	// configuration of the chain.
+	AccAddressBytesKeyEncoder KeyEncoder[sdk.AccAddress] = addressBytesKey[sdk.AccAddress]{}
+	// ValAddressBytesKeyEncoder can be used to encode sdk.ValAddress keys as
+	// length prefixed raw bytes, it does not depend on the bech32 prefix
+	// configuration of the chain.
+	ValAddressBytesKeyEncoder KeyEncoder[sdk.ValAddress] = addressBytesKey[sdk.ValAddress]{}
+	// ConsAddressBytesKeyEncoder can be used to encode sdk.ConsAddress keys as
+	// length prefixed raw bytes, it does not depend on the bech32 prefix
+	// configuration of the chain.
+	ConsAddressBytesKeyEncoder KeyEncoder[sdk.ConsAddress] = addressBytesKey[sdk.ConsAddress]{}
 	// SdkDecKeyEncoder can be used to encode math.LegacyDec keys.
+	//
+	// Deprecated: the produced bytes do not preserve the numeric ordering of the
+	// keys and the decoder consumes the whole buffer, so it cannot be used as the
+	// first part of a Pair. Use DecKeyEncoder instead, existing state can be
+	// migrated using MigrateKeyEncoding.
 	SdkDecKeyEncoder KeyEncoder[math.LegacyDec] = sdkDecKeyEncoder{}
+	// DecKeyEncoder can be used to encode math.LegacyDec keys. The encoding is
+	// self-delimiting and preserves the numeric ordering of the keys, negative
+	// values included.
+	DecKeyEncoder KeyEncoder[math.LegacyDec] = decKeyEncoder{}
 )
 
 type stringKey struct{}
@@ -35,6 +88,8 @@ func (stringKey) Encode(s string) []byte {
 	return append([]byte(s), 0) // null terminate it for safe prefixing
 }
 
+func (stringKey) decodeCopies() bool { return true }
+
 func (stringKey) Decode(b []byte) (int, string) {
 	l := len(b)
 	if l < 1 {
@@ -50,16 +105,247 @@ func (stringKey) Decode(b []byte) (int, string) {
 	panic(fmt.Errorf("string is not null terminated: %s %s", b, HumanizeBytes(b)))
 }
 
+func (stringKey) Size(s string) int { return len(s) + 1 }
+
+func (stringKey) PutKey(buf []byte, s string) int {
+	if err := validString(s); err != nil {
+		panic(fmt.Errorf("invalid StringKey: %w", err))
+	}
+	n := copy(buf, s)
+	buf[n] = 0 // null terminate it for safe prefixing
+	return n + 1
+}
+
+type terminalStringKey struct{}
+
+func (terminalStringKey) Stringify(s string) string       { return s }
+func (terminalStringKey) Encode(s string) []byte          { return []byte(s) }
+func (terminalStringKey) decodeCopies() bool              { return true }
+func (terminalStringKey) Decode(b []byte) (int, string)   { return len(b), string(b) }
+func (terminalStringKey) Size(s string) int               { return len(s) }
+func (terminalStringKey) PutKey(buf []byte, s string) int { return copy(buf, s) }
+
 type uint64Key struct{}
 
 func (uint64Key) Stringify(u uint64) string     { return strconv.FormatUint(u, 10) }
 func (uint64Key) Encode(u uint64) []byte        { return sdk.Uint64ToBigEndian(u) }
+func (uint64Key) decodeCopies() bool            { return true }
 func (uint64Key) Decode(b []byte) (int, uint64) { return 8, sdk.BigEndianToUint64(b) }
+func (uint64Key) Size(uint64) int               { return 8 }
+
+func (uint64Key) PutKey(buf []byte, u uint64) int {
+	binary.BigEndian.PutUint64(buf, u)
+	return 8
+}
+
+type uint32Key struct{}
+
+func (uint32Key) Stringify(u uint32) string { return strconv.FormatUint(uint64(u), 10) }
+func (uint32Key) Encode(u uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, u)
+	return b
+}
+
+func (uint32Key) decodeCopies() bool { return true }
+
+func (uint32Key) Decode(b []byte) (int, uint32) {
+	if len(b) < 4 {
+		panic(fmt.Errorf("invalid Uint32Key bytes. Uint32Key must be at least length 4. %s", HumanizeBytes(b)))
+	}
+	return 4, binary.BigEndian.Uint32(b)
+}
+
+func (uint32Key) Size(uint32) int { return 4 }
+
+func (uint32Key) PutKey(buf []byte, u uint32) int {
+	binary.BigEndian.PutUint32(buf, u)
+	return 4
+}
+
+type uint16Key struct{}
+
+func (uint16Key) Stringify(u uint16) string { return strconv.FormatUint(uint64(u), 10) }
+func (uint16Key) Encode(u uint16) []byte {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, u)
+	return b
+}
+
+func (uint16Key) decodeCopies() bool { return true }
+
+func (uint16Key) Decode(b []byte) (int, uint16) {
+	if len(b) < 2 {
+		panic(fmt.Errorf("invalid Uint16Key bytes. Uint16Key must be at least length 2. %s", HumanizeBytes(b)))
+	}
+	return 2, binary.BigEndian.Uint16(b)
+}
+
+func (uint16Key) Size(uint16) int { return 2 }
+
+func (uint16Key) PutKey(buf []byte, u uint16) int {
+	binary.BigEndian.PutUint16(buf, u)
+	return 2
+}
+
+type uint8Key struct{}
+
+func (uint8Key) Stringify(u uint8) string { return strconv.FormatUint(uint64(u), 10) }
+func (uint8Key) Encode(u uint8) []byte    { return []byte{u} }
+func (uint8Key) decodeCopies() bool       { return true }
+func (uint8Key) Decode(b []byte) (int, uint8) {
+	if len(b) < 1 {
+		panic(fmt.Errorf("invalid Uint8Key bytes. Uint8Key must be at least length 1. %s", HumanizeBytes(b)))
+	}
+	return 1, b[0]
+}
+
+func (uint8Key) Size(uint8) int { return 1 }
+
+func (uint8Key) PutKey(buf []byte, u uint8) int {
+	buf[0] = u
+	return 1
+}
+
+type boolKey struct{}
+
+func (boolKey) Stringify(b bool) string { return strconv.FormatBool(b) }
+func (boolKey) Encode(b bool) []byte {
+	if b {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+func (boolKey) decodeCopies() bool { return true }
+
+func (boolKey) Decode(b []byte) (int, bool) {
+	if len(b) < 1 {
+		panic(fmt.Errorf("invalid BoolKey bytes. BoolKey must be at least length 1. %s", HumanizeBytes(b)))
+	}
+	switch b[0] {
+	case 0:
+		return 1, false
+	case 1:
+		return 1, true
+	default:
+		panic(fmt.Errorf("invalid BoolKey byte: %d %s", b[0], HumanizeBytes(b)))
+	}
+}
+
+func (boolKey) Size(bool) int { return 1 }
+
+func (boolKey) PutKey(buf []byte, b bool) int {
+	buf[0] = 0
+	if b {
+		buf[0] = 1
+	}
+	return 1
+}
+
+// Signed integer keys are encoded in big endian with the sign bit flipped,
+// this way negative numbers sort before positive ones and the byte ordering
+// of the keys matches their numeric ordering.
+
+type int64Key struct{}
+
+func (int64Key) Stringify(i int64) string { return strconv.FormatInt(i, 10) }
+func (int64Key) Encode(i int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(i)^(1<<63))
+	return b
+}
+
+func (int64Key) decodeCopies() bool { return true }
+
+func (int64Key) Decode(b []byte) (int, int64) {
+	if len(b) < 8 {
+		panic(fmt.Errorf("invalid Int64Key bytes. Int64Key must be at least length 8. %s", HumanizeBytes(b)))
+	}
+	return 8, int64(binary.BigEndian.Uint64(b) ^ (1 << 63))
+}
+
+func (int64Key) Size(int64) int { return 8 }
+
+func (int64Key) PutKey(buf []byte, i int64) int {
+	binary.BigEndian.PutUint64(buf, uint64(i)^(1<<63))
+	return 8
+}
+
+type int32Key struct{}
+
+func (int32Key) Stringify(i int32) string { return strconv.FormatInt(int64(i), 10) }
+func (int32Key) Encode(i int32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(i)^(1<<31))
+	return b
+}
+
+func (int32Key) decodeCopies() bool { return true }
+
+func (int32Key) Decode(b []byte) (int, int32) {
+	if len(b) < 4 {
+		panic(fmt.Errorf("invalid Int32Key bytes. Int32Key must be at least length 4. %s", HumanizeBytes(b)))
+	}
+	return 4, int32(binary.BigEndian.Uint32(b) ^ (1 << 31))
+}
+
+func (int32Key) Size(int32) int { return 4 }
+
+func (int32Key) PutKey(buf []byte, i int32) int {
+	binary.BigEndian.PutUint32(buf, uint32(i)^(1<<31))
+	return 4
+}
+
+type int16Key struct{}
+
+func (int16Key) Stringify(i int16) string { return strconv.FormatInt(int64(i), 10) }
+func (int16Key) Encode(i int16) []byte {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, uint16(i)^(1<<15))
+	return b
+}
+
+func (int16Key) decodeCopies() bool { return true }
+
+func (int16Key) Decode(b []byte) (int, int16) {
+	if len(b) < 2 {
+		panic(fmt.Errorf("invalid Int16Key bytes. Int16Key must be at least length 2. %s", HumanizeBytes(b)))
+	}
+	return 2, int16(binary.BigEndian.Uint16(b) ^ (1 << 15))
+}
+
+func (int16Key) Size(int16) int { return 2 }
+
+func (int16Key) PutKey(buf []byte, i int16) int {
+	binary.BigEndian.PutUint16(buf, uint16(i)^(1<<15))
+	return 2
+}
+
+type int8Key struct{}
+
+func (int8Key) Stringify(i int8) string { return strconv.FormatInt(int64(i), 10) }
+func (int8Key) Encode(i int8) []byte    { return []byte{uint8(i) ^ (1 << 7)} }
+func (int8Key) decodeCopies() bool      { return true }
+func (int8Key) Decode(b []byte) (int, int8) {
+	if len(b) < 1 {
+		panic(fmt.Errorf("invalid Int8Key bytes. Int8Key must be at least length 1. %s", HumanizeBytes(b)))
+	}
+	return 1, int8(b[0] ^ (1 << 7))
+}
+
+func (int8Key) Size(int8) int { return 1 }
+
+func (int8Key) PutKey(buf []byte, i int8) int {
+	buf[0] = uint8(i) ^ (1 << 7)
+	return 1
+}
 
 type timeKey struct{}
 
 func (timeKey) Stringify(t time.Time) string { return t.String() }
 func (timeKey) Encode(t time.Time) []byte    { return sdk.FormatTimeBytes(t) }
+func (timeKey) decodeCopies() bool           { return true }
 func (timeKey) Decode(b []byte) (int, time.Time) {
 	t, err := sdk.ParseTimeBytes(b)
 	if err != nil {
@@ -68,6 +354,51 @@ func (timeKey) Decode(b []byte) (int, time.Time) {
 	return len(b), t
 }
 
+// Size returns the length of the sortable time format, which is fixed for the years 0 to 9999.
+func (timeKey) Size(t time.Time) int {
+	if y := t.UTC().Year(); y >= 0 && y <= 9999 {
+		return len(sdk.SortableTimeFormat)
+	}
+	return len(sdk.FormatTimeString(t))
+}
+
+func (timeKey) PutKey(buf []byte, t time.Time) int {
+	return copy(buf, t.UTC().Round(0).AppendFormat(buf[:0], sdk.SortableTimeFormat))
+}
+
+// minUnixNanoTime and maxUnixNanoTime are the bounds of the times
+// which can be represented as int64 unix nanoseconds.
+var (
+	minUnixNanoTime = time.Unix(0, stdmath.MinInt64)
+	maxUnixNanoTime = time.Unix(0, stdmath.MaxInt64)
+)
+
+type timeUnixNanoKey struct{}
+
+func (timeUnixNanoKey) Stringify(t time.Time) string { return t.String() }
+func (timeUnixNanoKey) Encode(t time.Time) []byte {
+	if t.Before(minUnixNanoTime) || t.After(maxUnixNanoTime) {
+		panic(fmt.Errorf("time %s cannot be represented as unix nanoseconds", t))
+	}
+	return Int64KeyEncoder.Encode(t.UnixNano())
+}
+
+func (timeUnixNanoKey) decodeCopies() bool { return true }
+
+func (timeUnixNanoKey) Decode(b []byte) (int, time.Time) {
+	i, n := Int64KeyEncoder.Decode(b)
+	return i, time.Unix(0, n).UTC()
+}
+
+func (timeUnixNanoKey) Size(time.Time) int { return 8 }
+
+func (timeUnixNanoKey) PutKey(buf []byte, t time.Time) int {
+	if t.Before(minUnixNanoTime) || t.After(maxUnixNanoTime) {
+		panic(fmt.Errorf("time %s cannot be represented as unix nanoseconds", t))
+	}
+	return int64Key{}.PutKey(buf, t.UnixNano())
+}
+
 type accAddressKey struct{}
 
 func (accAddressKey) Stringify(addr sdk.AccAddress) string { return addr.String() }
@@ -75,17 +406,76 @@ func (accAddressKey) Encode(addr sdk.AccAddress) []byte {
 	return StringKeyEncoder.Encode(addr.String())
 }
 
+func (accAddressKey) decodeCopies() bool { return true }
+
 func (accAddressKey) Decode(b []byte) (int, sdk.AccAddress) {
 	i, s := StringKeyEncoder.Decode(b)
 	return i, sdk.MustAccAddressFromBech32(s)
 }
 
+// addressBytesKey encodes addresses as their raw bytes prefixed by their length.
+// Existing bech32 keyed state can be migrated using MigrateKeyEncoding.
+type addressBytesKey[T interface {
+	~[]byte
+	String() string
+}] struct{}
+
+func (addressBytesKey[T]) Stringify(addr T) string { return addr.String() }
+func (addressBytesKey[T]) Encode(addr T) []byte {
+	return encodeLengthPrefixed(addr)
+}
+
+func (addressBytesKey[T]) decodeCopies() bool { return true }
+
+func (addressBytesKey[T]) Decode(b []byte) (int, T) {
+	i, addr := decodeLengthPrefixed(b)
+	return i, addr
+}
+
+func (addressBytesKey[T]) Size(addr T) int               { return 1 + len(addr) }
+func (addressBytesKey[T]) PutKey(buf []byte, addr T) int { return putLengthPrefixed(buf, addr) }
+
+// encodeLengthPrefixed returns the bytes prefixed by their length.
+// Panics if the bytes are longer than 255.
+func encodeLengthPrefixed(bz []byte) []byte {
+	b := make([]byte, 1+len(bz))
+	putLengthPrefixed(b, bz)
+	return b
+}
+
+// putLengthPrefixed writes the bytes prefixed by their length into buf,
+// and returns the number of bytes written. Panics if the bytes are longer than 255.
+func putLengthPrefixed(buf, bz []byte) int {
+	if len(bz) > stdmath.MaxUint8 {
+		panic(fmt.Errorf("length prefixed bytes cannot be longer than %d: %s", stdmath.MaxUint8, HumanizeBytes(bz)))
+	}
+	buf[0] = byte(len(bz))
+	return 1 + copy(buf[1:], bz)
+}
+
+// decodeLengthPrefixed decodes bytes encoded with encodeLengthPrefixed,
+// the returned bytes are a copy of the provided ones.
+func decodeLengthPrefixed(b []byte) (int, []byte) {
+	if len(b) < 1 {
+		panic(fmt.Errorf("invalid length prefixed bytes. must be at least length 1. %s", HumanizeBytes(b)))
+	}
+	l := int(b[0])
+	if len(b) < 1+l {
+		panic(fmt.Errorf("invalid length prefixed bytes. expected length %d: %s", 1+l, HumanizeBytes(b)))
+	}
+	bz := make([]byte, l)
+	copy(bz, b[1:1+l])
+	return 1 + l, bz
+}
+
 type valAddressKeyEncoder struct{}
 
 func (v valAddressKeyEncoder) Encode(key sdk.ValAddress) []byte {
 	return StringKeyEncoder.Encode(key.String())
 }
 
+func (valAddressKeyEncoder) decodeCopies() bool { return true }
+
 func (v valAddressKeyEncoder) Decode(b []byte) (int, sdk.ValAddress) {
 	r, s := StringKeyEncoder.Decode(b)
 	valAddr, err := sdk.ValAddressFromBech32(s)
@@ -115,6 +505,8 @@ func (consAddressKeyEncoder) Encode(key sdk.ConsAddress) []byte {
 	return StringKeyEncoder.Encode(key.String())
 }
 
+func (consAddressKeyEncoder) decodeCopies() bool { return true }
+
 func (consAddressKeyEncoder) Decode(b []byte) (int, sdk.ConsAddress) {
 	r, s := StringKeyEncoder.Decode(b)
 	consAddr, err := sdk.ConsAddressFromBech32(s)
@@ -137,6 +529,8 @@ func (sdkDecKeyEncoder) Encode(key math.LegacyDec) []byte {
 	return bz
 }
 
+func (sdkDecKeyEncoder) decodeCopies() bool { return true }
+
 func (sdkDecKeyEncoder) Decode(b []byte) (int, math.LegacyDec) {
 	var dec math.LegacyDec
 	if err := dec.Unmarshal(b); err != nil {
@@ -146,6 +540,116 @@ func (sdkDecKeyEncoder) Decode(b []byte) (int, math.LegacyDec) {
 	return len(b), dec
 }
 
+// decKeyEncoder encodes math.LegacyDec keys in an order preserving way.
+// The key is composed of a sign byte followed, for non-zero values,
+// by the length of the absolute value of the underlying big.Int and
+// its big endian bytes:
+//   - negative: 0x00 | ^len | ^bytes
+//   - zero:     0x01
+//   - positive: 0x02 | len | bytes
+//
+// Since the bytes have no leading zeros, a longer absolute value is always
+// bigger. The length and the bytes of negative values are complemented
+// so that a bigger absolute value sorts first.
+type decKeyEncoder struct{}
+
+const (
+	decKeyNegative byte = iota
+	decKeyZero
+	decKeyPositive
+)
+
+func (decKeyEncoder) Stringify(key math.LegacyDec) string { return key.String() }
+
+func (decKeyEncoder) Encode(key math.LegacyDec) []byte {
+	if key.IsNil() {
+		panic("cannot encode invalid math.LegacyDec")
+	}
+	if key.IsZero() {
+		return []byte{decKeyZero}
+	}
+	abs := new(big.Int).Abs(key.BigInt()).Bytes()
+	b := make([]byte, 0, 2+len(abs))
+	if key.IsPositive() {
+		b = append(b, decKeyPositive, byte(len(abs)))
+		return append(b, abs...)
+	}
+	b = append(b, decKeyNegative, ^byte(len(abs)))
+	for _, c := range abs {
+		b = append(b, ^c)
+	}
+	return b
+}
+
+func (decKeyEncoder) decodeCopies() bool { return true }
+
+func (decKeyEncoder) Decode(b []byte) (int, math.LegacyDec) {
+	if len(b) < 1 {
+		panic(fmt.Errorf("invalid DecKey bytes. DecKey must be at least length 1. %s", HumanizeBytes(b)))
+	}
+	switch b[0] {
+	case decKeyZero:
+		return 1, math.LegacyZeroDec()
+	case decKeyPositive, decKeyNegative:
+	default:
+		panic(fmt.Errorf("invalid DecKey sign byte: %d %s", b[0], HumanizeBytes(b)))
+	}
+	if len(b) < 2 {
+		panic(fmt.Errorf("invalid DecKey bytes. missing length: %s", HumanizeBytes(b)))
+	}
+	negative := b[0] == decKeyNegative
+	l := int(b[1])
+	if negative {
+		l = int(^b[1])
+	}
+	if len(b) < 2+l {
+		panic(fmt.Errorf("invalid DecKey bytes. expected length %d: %s", 2+l, HumanizeBytes(b)))
+	}
+	abs := make([]byte, l)
+	copy(abs, b[2:2+l])
+	if negative {
+		for i := range abs {
+			abs[i] = ^abs[i]
+		}
+	}
+	i := new(big.Int).SetBytes(abs)
+	if negative {
+		i.Neg(i)
+	}
+	return 2 + l, math.LegacyNewDecFromBigIntWithPrec(i, math.LegacyPrecision)
+}
+
+func (decKeyEncoder) Size(key math.LegacyDec) int {
+	if key.IsNil() {
+		panic("cannot encode invalid math.LegacyDec")
+	}
+	if key.IsZero() {
+		return 1
+	}
+	return 2 + (key.BigIntMut().BitLen()+7)/8
+}
+
+func (decKeyEncoder) PutKey(buf []byte, key math.LegacyDec) int {
+	if key.IsNil() {
+		panic("cannot encode invalid math.LegacyDec")
+	}
+	if key.IsZero() {
+		buf[0] = decKeyZero
+		return 1
+	}
+	i := key.BigIntMut()
+	l := (i.BitLen() + 7) / 8
+	// FillBytes writes the absolute value.
+	i.FillBytes(buf[2 : 2+l])
+	if key.IsPositive() {
+		buf[0], buf[1] = decKeyPositive, byte(l)
+		return 2 + l
+	}
+	buf[0], buf[1] = decKeyNegative, ^byte(l)
+	complementBytes(buf[2 : 2+l])
+	return 2 + l
+}
+
 // HumanizeBytes is a shorthand function for converting a slice of bytes ([]byte)
 // into to hexadecimal string with a short descriptor. This function is meant to
 // make error messages more readable since the bytes will be reproducable.
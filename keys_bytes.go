@@ -0,0 +1,96 @@
+package collections
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+var (
+	// BytesKeyEncoder can be used to encode []byte keys. The bytes are prefixed
+	// by their length, so the keys can be used in any part of a composite key.
+	// The maximum length of the bytes is 255. Due to the prefix keys are
+	// ordered by length first and then lexicographically.
+	BytesKeyEncoder KeyEncoder[[]byte] = bytesKey{}
+	// TerminalBytesKeyEncoder can be used to encode []byte keys which are not
+	// length prefixed. The decoder consumes the whole buffer, so it can only be
+	// used alone or as the last part of a composite key.
+	TerminalBytesKeyEncoder KeyEncoder[[]byte] = terminalBytesKey{}
+)
+
+type bytesKey struct{}
+
+func (bytesKey) Stringify(key []byte) string   { return hex.EncodeToString(key) }
+func (bytesKey) Encode(key []byte) []byte      { return encodeLengthPrefixed(key) }
+func (bytesKey) decodeCopies() bool            { return true }
+func (bytesKey) Decode(b []byte) (int, []byte) { return decodeLengthPrefixed(b) }
+func (bytesKey) Size(key []byte) int           { return 1 + len(key) }
+func (bytesKey) PutKey(buf, key []byte) int    { return putLengthPrefixed(buf, key) }
+
+type terminalBytesKey struct{}
+
+func (terminalBytesKey) Stringify(key []byte) string { return hex.EncodeToString(key) }
+
+func (terminalBytesKey) Encode(key []byte) []byte {
+	b := make([]byte, len(key))
+	copy(b, key)
+	return b
+}
+
+func (terminalBytesKey) decodeCopies() bool { return true }
+
+func (terminalBytesKey) Decode(b []byte) (int, []byte) {
+	key := make([]byte, len(b))
+	copy(key, b)
+	return len(b), key
+}
+
+func (terminalBytesKey) Size(key []byte) int        { return len(key) }
+func (terminalBytesKey) PutKey(buf, key []byte) int { return copy(buf, key) }
+
+// FixedBytes is the constraint satisfied by the fixed size byte arrays
+// which can be used as keys through FixedBytesKeyEncoder.
+type FixedBytes interface {
+	~[8]byte | ~[16]byte | ~[20]byte | ~[32]byte | ~[64]byte
+}
+
+// FixedBytesKeyEncoder returns a KeyEncoder for fixed size byte arrays,
+// like hashes or EVM addresses. Since the size is known, the bytes
+// are not length prefixed and the keys can be used in any part of
+// a composite key.
+func FixedBytesKeyEncoder[T FixedBytes]() KeyEncoder[T] { return fixedBytesKey[T]{} }
+
+type fixedBytesKey[T FixedBytes] struct{}
+
+func (fixedBytesKey[T]) Stringify(key T) string {
+	return hex.EncodeToString(fixedBytesKey[T]{}.Encode(key))
+}
+
+func (fixedBytesKey[T]) Encode(key T) []byte {
+	b := make([]byte, len(key))
+	for i := range b {
+		b[i] = key[i]
+	}
+	return b
+}
+
+func (fixedBytesKey[T]) decodeCopies() bool { return true }
+
+func (fixedBytesKey[T]) Decode(b []byte) (int, T) {
+	var key T
+	if len(b) < len(key) {
+		panic(fmt.Errorf("invalid fixed bytes key. must be at least length %d: %s", len(key), HumanizeBytes(b)))
+	}
+	for i := 0; i < len(key); i++ {
+		key[i] = b[i]
+	}
+	return len(key), key
+}
+
+func (fixedBytesKey[T]) Size(key T) int { return len(key) }
+
+func (fixedBytesKey[T]) PutKey(buf []byte, key T) int {
+	for i := 0; i < len(key); i++ {
+		buf[i] = key[i]
+	}
+	return len(key)
+}
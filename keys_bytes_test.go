@@ -0,0 +1,61 @@
+package collections
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBytesKey(t *testing.T) {
+	t.Run("bijective", func(t *testing.T) {
+		assertBijective(t, BytesKeyEncoder, []byte("hash"))
+		assertBijective(t, BytesKeyEncoder, []byte{})
+		assertBijective(t, BytesKeyEncoder, bytes.Repeat([]byte{0xFF}, 255))
+	})
+
+	t.Run("pair prefix", func(t *testing.T) {
+		enc := PairKeyEncoder(BytesKeyEncoder, TerminalBytesKeyEncoder)
+		assertBijective(t, enc, Join([]byte{0x0, 0x1}, []byte("terminal")))
+	})
+
+	t.Run("stringify", func(t *testing.T) {
+		require.Equal(t, "0aff", BytesKeyEncoder.Stringify([]byte{0x0a, 0xff}))
+	})
+
+	t.Run("panics", func(t *testing.T) {
+		require.Panics(t, func() { BytesKeyEncoder.Encode(make([]byte, 256)) })
+		require.Panics(t, func() { BytesKeyEncoder.Decode([]byte{}) })
+		require.Panics(t, func() { BytesKeyEncoder.Decode([]byte{0x3, 0x1}) })
+	})
+}
+
+func TestTerminalBytesKey(t *testing.T) {
+	assertBijective(t, TerminalBytesKeyEncoder, []byte("tx-id"))
+	assertBijective(t, TerminalBytesKeyEncoder, []byte{})
+	require.Equal(t, []byte("tx-id"), TerminalBytesKeyEncoder.Encode([]byte("tx-id")))
+}
+
+func TestFixedBytesKey(t *testing.T) {
+	type evmAddress [20]byte
+
+	t.Run("bijective", func(t *testing.T) {
+		assertBijective(t, FixedBytesKeyEncoder[evmAddress](), evmAddress{0x1, 0x2})
+		assertBijective(t, FixedBytesKeyEncoder[[32]byte](), [32]byte{31: 0xFF})
+	})
+
+	t.Run("encoding", func(t *testing.T) {
+		key := [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+		require.Equal(t, key[:], FixedBytesKeyEncoder[[8]byte]().Encode(key))
+		require.Equal(t, "0102030405060708", FixedBytesKeyEncoder[[8]byte]().Stringify(key))
+	})
+
+	t.Run("pair prefix", func(t *testing.T) {
+		enc := PairKeyEncoder(FixedBytesKeyEncoder[[32]byte](), Uint64KeyEncoder)
+		assertBijective(t, enc, Join([32]byte{0: 0x1}, uint64(10)))
+	})
+
+	t.Run("panics", func(t *testing.T) {
+		require.Panics(t, func() { FixedBytesKeyEncoder[[32]byte]().Decode(make([]byte, 31)) })
+	})
+}
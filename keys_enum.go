@@ -0,0 +1,96 @@
+package collections
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+)
+
+// EnumKeyEncoder returns a KeyEncoder for protobuf enums, given the
+// enum name map generated by protoc (ex: stakingtypes.BondStatus_name).
+// The name map is used only to make the keys human-readable when
+// they are stringified, an unknown enum value is stringified as its number.
+//
+// The enum is encoded compactly, preserving its numeric ordering:
+//   - negative values: 0x00 | 4 bytes of the sign flipped value
+//   - values from 0 to 253: a single byte equal to value+1
+//   - values from 254: 0xFF | 4 bytes of the big endian value
+//
+// Most enums hence take a single byte.
+func EnumKeyEncoder[T ~int32](names map[int32]string) KeyEncoder[T] {
+	return enumKey[T]{names: names}
+}
+
+const (
+	enumKeyNegative byte = 0x00
+	enumKeyLarge    byte = 0xFF
+	// enumKeyMaxSmall is the biggest value encoded in a single byte.
+	enumKeyMaxSmall = int32(enumKeyLarge) - 2
+)
+
+type enumKey[T ~int32] struct {
+	names map[int32]string
+}
+
+func (e enumKey[T]) Stringify(key T) string {
+	name, ok := e.names[int32(key)]
+	if !ok {
+		return strconv.FormatInt(int64(key), 10)
+	}
+	return name
+}
+
+func (enumKey[T]) Encode(key T) []byte {
+	v := int32(key)
+	switch {
+	case v < 0:
+		return append([]byte{enumKeyNegative}, Int32KeyEncoder.Encode(v)...)
+	case v <= enumKeyMaxSmall:
+		return []byte{byte(v + 1)}
+	default:
+		b := make([]byte, 5)
+		b[0] = enumKeyLarge
+		binary.BigEndian.PutUint32(b[1:], uint32(v))
+		return b
+	}
+}
+
+func (enumKey[T]) decodeCopies() bool { return true }
+
+func (enumKey[T]) Decode(b []byte) (int, T) {
+	if len(b) < 1 {
+		panic(fmt.Errorf("invalid EnumKey bytes. EnumKey must be at least length 1. %s", HumanizeBytes(b)))
+	}
+	switch b[0] {
+	case enumKeyNegative:
+		_, v := Int32KeyEncoder.Decode(b[1:])
+		return 5, T(v)
+	case enumKeyLarge:
+		_, v := Uint32KeyEncoder.Decode(b[1:])
+		return 5, T(v)
+	default:
+		return 1, T(b[0] - 1)
+	}
+}
+
+func (enumKey[T]) Size(key T) int {
+	if v := int32(key); v >= 0 && v <= enumKeyMaxSmall {
+		return 1
+	}
+	return 5
+}
+
+func (enumKey[T]) PutKey(buf []byte, key T) int {
+	v := int32(key)
+	switch {
+	case v < 0:
+		buf[0] = enumKeyNegative
+		return 1 + int32Key{}.PutKey(buf[1:], v)
+	case v <= enumKeyMaxSmall:
+		buf[0] = byte(v + 1)
+		return 1
+	default:
+		buf[0] = enumKeyLarge
+		return 1 + uint32Key{}.PutKey(buf[1:], uint32(v))
+	}
+}
@@ -0,0 +1,47 @@
+package collections
+
+import (
+	"testing"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumKey(t *testing.T) {
+	enc := EnumKeyEncoder[stakingtypes.BondStatus](stakingtypes.BondStatus_name)
+
+	t.Run("bijective", func(t *testing.T) {
+		for _, v := range []int32{-1 << 31, -1, 0, 1, 253, 254, 255, 1<<31 - 1} {
+			assertBijective(t, enc, stakingtypes.BondStatus(v))
+		}
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		require.Equal(t, []byte{0x4}, enc.Encode(stakingtypes.Bonded))
+		require.Len(t, enc.Encode(-1), 5)
+		require.Len(t, enc.Encode(254), 5)
+	})
+
+	t.Run("proper ordering", func(t *testing.T) {
+		assertOrdered(t, enc, []stakingtypes.BondStatus{
+			-1 << 31, -256, -1, stakingtypes.Unspecified, stakingtypes.Unbonded,
+			stakingtypes.Unbonding, stakingtypes.Bonded, 253, 254, 255, 1<<31 - 1,
+		})
+	})
+
+	t.Run("stringify", func(t *testing.T) {
+		require.Equal(t, "BOND_STATUS_BONDED", enc.Stringify(stakingtypes.Bonded))
+		require.Equal(t, "100", enc.Stringify(100))
+	})
+
+	t.Run("pair prefix", func(t *testing.T) {
+		assertBijective(t, PairKeyEncoder(enc, StringKeyEncoder), Join(stakingtypes.Bonded, "validator"))
+		assertBijective(t, PairKeyEncoder(enc, StringKeyEncoder), Join(stakingtypes.BondStatus(-5), "validator"))
+	})
+
+	t.Run("panics", func(t *testing.T) {
+		require.Panics(t, func() { enc.Decode([]byte{}) })
+		require.Panics(t, func() { enc.Decode([]byte{enumKeyLarge, 0x1}) })
+		require.Panics(t, func() { enc.Decode([]byte{enumKeyNegative}) })
+	})
+}
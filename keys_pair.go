@@ -4,10 +4,16 @@ import "strings"
 
 // PairKeyEncoder creates a new KeyEncoder for Pair types, give the two key encoders for K1 and K2.
 func PairKeyEncoder[K1, K2 any](kc1 KeyEncoder[K1], kc2 KeyEncoder[K2]) KeyEncoder[Pair[K1, K2]] {
-	return pairKeyEncoder[K1, K2]{
+	p := pairKeyEncoder[K1, K2]{
 		kc1: kc1,
 		kc2: kc2,
 	}
+	sk1, ok1 := kc1.(SizedKeyEncoder[K1])
+	sk2, ok2 := kc2.(SizedKeyEncoder[K2])
+	if ok1 && ok2 {
+		return sizedPairKeyEncoder[K1, K2]{pairKeyEncoder: p, sk1: sk1, sk2: sk2}
+	}
+	return p
 }
 
 type pairKeyEncoder[K1, K2 any] struct {
@@ -54,24 +60,61 @@ func (p pairKeyEncoder[K1, K2]) Encode(key Pair[K1, K2]) []byte {
 	}
 }
 
+func (p pairKeyEncoder[K1, K2]) decodeCopies() bool {
+	return decodeCopies(p.kc1) && decodeCopies(p.kc2)
+}
+
 // Decode decodes the Pair. It assumes that the provided bytes contain both the K1 and K2 part.
 func (p pairKeyEncoder[K1, K2]) Decode(b []byte) (int, Pair[K1, K2]) {
 	// NOTE(mercilex): is it always safe to assume that when we get a part
 	// of the key it's going to always contain the full key and not only a part?
 	i1, k1 := p.kc1.Decode(b)
 	i2, k2 := p.kc2.Decode(b[i1:])
-	return i1 + i2, Pair[K1, K2]{
-		k1: &k1,
-		k2: &k2,
+	return i1 + i2, Join(k1, k2)
+}
+
+// sizedPairKeyEncoder is the pairKeyEncoder of parts which are SizedKeyEncoder,
+// which are kept to avoid asserting their type on every key.
+type sizedPairKeyEncoder[K1, K2 any] struct {
+	pairKeyEncoder[K1, K2]
+	sk1 SizedKeyEncoder[K1]
+	sk2 SizedKeyEncoder[K2]
+}
+
+// Encode encodes the Pair with a single allocation.
+func (p sizedPairKeyEncoder[K1, K2]) Encode(key Pair[K1, K2]) []byte {
+	buf := make([]byte, p.Size(key))
+	return buf[:p.PutKey(buf, key)]
+}
+
+// Size returns the size of the encoded Pair.
+func (p sizedPairKeyEncoder[K1, K2]) Size(key Pair[K1, K2]) int {
+	if key.k1 == nil && key.k2 == nil {
+		panic("empty Pair key")
 	}
+	return sizePart(p.sk1, key.k1) + sizePart(p.sk2, key.k2)
+}
+
+// PutKey writes the present parts of the Pair into the buffer.
+func (p sizedPairKeyEncoder[K1, K2]) PutKey(buf []byte, key Pair[K1, K2]) int {
+	if key.k1 == nil && key.k2 == nil {
+		panic("empty Pair key")
+	}
+	n := putPart(p.sk1, buf, key.k1)
+	return n + putPart(p.sk2, buf[n:], key.k2)
 }
 
 // Join returns a fully populated Pair
 // given the two key parts.
 func Join[K1, K2 any](k1 K1, k2 K2) Pair[K1, K2] {
+	// both parts share a single allocation.
+	parts := &struct {
+		k1 K1
+		k2 K2
+	}{k1, k2}
 	return Pair[K1, K2]{
-		k1: &k1,
-		k2: &k2,
+		k1: &parts.k1,
+		k2: &parts.k2,
 	}
 }
 
@@ -111,3 +111,29 @@ func TestPairRange(t *testing.T) {
 		rng.RangeValues()
 	})
 }
+
+func TestPairRangeSigned(t *testing.T) {
+	sk, ctx, _ := deps()
+
+	ks := NewKeySet[Pair[int32, int64]](
+		sk,
+		0,
+		PairKeyEncoder[int32, int64](Int32KeyEncoder, Int64KeyEncoder),
+	)
+	items := []Pair[int32, int64]{
+		Join(int32(-1), int64(-5)),
+		Join(int32(-1), int64(-1)),
+		Join(int32(-1), int64(0)),
+		Join(int32(-1), int64(7)),
+		Join(int32(0), int64(-5)),
+	}
+	for _, i := range items {
+		ks.Insert(ctx, i)
+	}
+
+	results := ks.Iterate(ctx, PairRange[int32, int64]{}.Prefix(-1).StartInclusive(-1).EndExclusive(7)).Keys()
+	require.Equal(t, items[1:3], results)
+
+	results = ks.Iterate(ctx, Range[Pair[int32, int64]]{}).Keys()
+	require.Equal(t, items, results)
+}
@@ -0,0 +1,412 @@
+package collections
+
+// QuadKeyEncoder creates a new KeyEncoder for Quad types, given the four key encoders for K1, K2, K3 and K4.
+func QuadKeyEncoder[K1, K2, K3, K4 any](
+	kc1 KeyEncoder[K1], kc2 KeyEncoder[K2], kc3 KeyEncoder[K3], kc4 KeyEncoder[K4],
+) KeyEncoder[Quad[K1, K2, K3, K4]] {
+	q := quadKeyEncoder[K1, K2, K3, K4]{
+		kc1: kc1,
+		kc2: kc2,
+		kc3: kc3,
+		kc4: kc4,
+	}
+	sk1, ok1 := kc1.(SizedKeyEncoder[K1])
+	sk2, ok2 := kc2.(SizedKeyEncoder[K2])
+	sk3, ok3 := kc3.(SizedKeyEncoder[K3])
+	sk4, ok4 := kc4.(SizedKeyEncoder[K4])
+	if ok1 && ok2 && ok3 && ok4 {
+		return sizedQuadKeyEncoder[K1, K2, K3, K4]{quadKeyEncoder: q, sk1: sk1, sk2: sk2, sk3: sk3, sk4: sk4}
+	}
+	return q
+}
+
+type quadKeyEncoder[K1, K2, K3, K4 any] struct {
+	kc1 KeyEncoder[K1]
+	kc2 KeyEncoder[K2]
+	kc3 KeyEncoder[K3]
+	kc4 KeyEncoder[K4]
+}
+
+// Stringify returns a string representation of the given Quad.
+func (q quadKeyEncoder[K1, K2, K3, K4]) Stringify(key Quad[K1, K2, K3, K4]) string {
+	return stringifyComposite(
+		stringifyPart(q.kc1, key.k1),
+		stringifyPart(q.kc2, key.k2),
+		stringifyPart(q.kc3, key.k3),
+		stringifyPart(q.kc4, key.k4),
+	)
+}
+
+// Encode encodes the Quad.
+// The byte versions of the present parts of the key are joined together in order.
+// Panics if no part is present.
+func (q quadKeyEncoder[K1, K2, K3, K4]) Encode(key Quad[K1, K2, K3, K4]) []byte {
+	if key.k1 == nil && key.k2 == nil && key.k3 == nil && key.k4 == nil {
+		panic("empty Quad key")
+	}
+	var b []byte
+	if key.k1 != nil {
+		b = append(b, q.kc1.Encode(*key.k1)...)
+	}
+	if key.k2 != nil {
+		b = append(b, q.kc2.Encode(*key.k2)...)
+	}
+	if key.k3 != nil {
+		b = append(b, q.kc3.Encode(*key.k3)...)
+	}
+	if key.k4 != nil {
+		b = append(b, q.kc4.Encode(*key.k4)...)
+	}
+	return b
+}
+
+func (q quadKeyEncoder[K1, K2, K3, K4]) decodeCopies() bool {
+	return decodeCopies(q.kc1) && decodeCopies(q.kc2) && decodeCopies(q.kc3) && decodeCopies(q.kc4)
+}
+
+// Decode decodes the Quad. It assumes that the provided bytes contain all the four parts.
+func (q quadKeyEncoder[K1, K2, K3, K4]) Decode(b []byte) (int, Quad[K1, K2, K3, K4]) {
+	i1, k1 := q.kc1.Decode(b)
+	i2, k2 := q.kc2.Decode(b[i1:])
+	i3, k3 := q.kc3.Decode(b[i1+i2:])
+	i4, k4 := q.kc4.Decode(b[i1+i2+i3:])
+	return i1 + i2 + i3 + i4, Quad[K1, K2, K3, K4]{
+		k1: &k1,
+		k2: &k2,
+		k3: &k3,
+		k4: &k4,
+	}
+}
+
+// sizedQuadKeyEncoder is the quadKeyEncoder of parts which are SizedKeyEncoder,
+// which are kept to avoid asserting their type on every key.
+type sizedQuadKeyEncoder[K1, K2, K3, K4 any] struct {
+	quadKeyEncoder[K1, K2, K3, K4]
+	sk1 SizedKeyEncoder[K1]
+	sk2 SizedKeyEncoder[K2]
+	sk3 SizedKeyEncoder[K3]
+	sk4 SizedKeyEncoder[K4]
+}
+
+// Encode encodes the Quad with a single allocation.
+func (q sizedQuadKeyEncoder[K1, K2, K3, K4]) Encode(key Quad[K1, K2, K3, K4]) []byte {
+	buf := make([]byte, q.Size(key))
+	return buf[:q.PutKey(buf, key)]
+}
+
+// Size returns the size of the encoded Quad.
+func (q sizedQuadKeyEncoder[K1, K2, K3, K4]) Size(key Quad[K1, K2, K3, K4]) int {
+	if key.k1 == nil && key.k2 == nil && key.k3 == nil && key.k4 == nil {
+		panic("empty Quad key")
+	}
+	return sizePart(q.sk1, key.k1) + sizePart(q.sk2, key.k2) + sizePart(q.sk3, key.k3) + sizePart(q.sk4, key.k4)
+}
+
+// PutKey writes the present parts of the Quad into the buffer.
+func (q sizedQuadKeyEncoder[K1, K2, K3, K4]) PutKey(buf []byte, key Quad[K1, K2, K3, K4]) int {
+	if key.k1 == nil && key.k2 == nil && key.k3 == nil && key.k4 == nil {
+		panic("empty Quad key")
+	}
+	n := putPart(q.sk1, buf, key.k1)
+	n += putPart(q.sk2, buf[n:], key.k2)
+	n += putPart(q.sk3, buf[n:], key.k3)
+	return n + putPart(q.sk4, buf[n:], key.k4)
+}
+
+// Join4 returns a fully populated Quad
+// given the four key parts.
+func Join4[K1, K2, K3, K4 any](k1 K1, k2 K2, k3 K3, k4 K4) Quad[K1, K2, K3, K4] {
+	return Quad[K1, K2, K3, K4]{
+		k1: &k1,
+		k2: &k2,
+		k3: &k3,
+		k4: &k4,
+	}
+}
+
+// QuadPrefix returns a partially populated Quad
+// given the first part of the key.
+func QuadPrefix[K1, K2, K3, K4 any](k1 K1) Quad[K1, K2, K3, K4] {
+	return Quad[K1, K2, K3, K4]{
+		k1: &k1,
+	}
+}
+
+// QuadSuperPrefix returns a partially populated Quad
+// given the first and the second part of the key.
+func QuadSuperPrefix[K1, K2, K3, K4 any](k1 K1, k2 K2) Quad[K1, K2, K3, K4] {
+	return Quad[K1, K2, K3, K4]{
+		k1: &k1,
+		k2: &k2,
+	}
+}
+
+// QuadSuperPrefix3 returns a partially populated Quad
+// given the first three parts of the key.
+func QuadSuperPrefix3[K1, K2, K3, K4 any](k1 K1, k2 K2, k3 K3) Quad[K1, K2, K3, K4] {
+	return Quad[K1, K2, K3, K4]{
+		k1: &k1,
+		k2: &k2,
+		k3: &k3,
+	}
+}
+
+// Quad defines a storage key composed of four keys of different or equal types.
+type Quad[K1, K2, K3, K4 any] struct {
+	k1 *K1
+	k2 *K2
+	k3 *K3
+	k4 *K4
+}
+
+func (q Quad[K1, K2, K3, K4]) K1() (k1 K1) {
+	if q.k1 != nil {
+		k1 = *q.k1
+	}
+	return
+}
+
+func (q Quad[K1, K2, K3, K4]) K2() (k2 K2) {
+	if q.k2 != nil {
+		k2 = *q.k2
+	}
+	return
+}
+
+func (q Quad[K1, K2, K3, K4]) K3() (k3 K3) {
+	if q.k3 != nil {
+		k3 = *q.k3
+	}
+	return
+}
+
+func (q Quad[K1, K2, K3, K4]) K4() (k4 K4) {
+	if q.k4 != nil {
+		k4 = *q.k4
+	}
+	return
+}
+
+// QuadRange implements the Ranger interface
+// to provide an easier way to range over Quad keys.
+// The range can be prefixed over K1, and bounded over K2,
+// or prefixed over more parts using SuperPrefix and SuperPrefix3.
+type QuadRange[K1, K2, K3, K4 any] struct {
+	prefix *K1
+	start  *Bound[K2]
+	end    *Bound[K2]
+	order  Order
+}
+
+// Prefix makes the range contain only keys starting with the given k1 prefix.
+func (q QuadRange[K1, K2, K3, K4]) Prefix(prefix K1) QuadRange[K1, K2, K3, K4] {
+	q.prefix = &prefix
+	return q
+}
+
+// SuperPrefix returns a range which contains only keys starting with the given k1 and k2
+// prefixes, the returned range can be bounded over K3.
+func (q QuadRange[K1, K2, K3, K4]) SuperPrefix(k1 K1, k2 K2) QuadSuperPrefixRange[K1, K2, K3, K4] {
+	return QuadSuperPrefixRange[K1, K2, K3, K4]{
+		k1:    k1,
+		k2:    k2,
+		order: q.order,
+	}
+}
+
+// SuperPrefix3 returns a range which contains only keys starting with the given k1, k2 and k3
+// prefixes, the returned range can be bounded over K4.
+func (q QuadRange[K1, K2, K3, K4]) SuperPrefix3(k1 K1, k2 K2, k3 K3) QuadSuperPrefix3Range[K1, K2, K3, K4] {
+	return QuadSuperPrefix3Range[K1, K2, K3, K4]{
+		k1:    k1,
+		k2:    k2,
+		k3:    k3,
+		order: q.order,
+	}
+}
+
+// StartInclusive makes the range contain only keys whose K2 is bigger or equal to the provided start K2.
+func (q QuadRange[K1, K2, K3, K4]) StartInclusive(start K2) QuadRange[K1, K2, K3, K4] {
+	q.start = BoundInclusive(start)
+	return q
+}
+
+// StartExclusive makes the range contain only keys whose K2 is bigger than the provided start K2.
+func (q QuadRange[K1, K2, K3, K4]) StartExclusive(start K2) QuadRange[K1, K2, K3, K4] {
+	q.start = BoundExclusive(start)
+	return q
+}
+
+// EndInclusive makes the range contain only keys whose K2 is smaller or equal to the provided end K2.
+func (q QuadRange[K1, K2, K3, K4]) EndInclusive(end K2) QuadRange[K1, K2, K3, K4] {
+	q.end = BoundInclusive(end)
+	return q
+}
+
+// EndExclusive makes the range contain only keys whose K2 is smaller than the provided end K2.
+func (q QuadRange[K1, K2, K3, K4]) EndExclusive(end K2) QuadRange[K1, K2, K3, K4] {
+	q.end = BoundExclusive(end)
+	return q
+}
+
+// Descending makes the range run in reverse (bigger->smaller, instead of smaller->bigger)
+func (q QuadRange[K1, K2, K3, K4]) Descending() QuadRange[K1, K2, K3, K4] {
+	q.order = OrderDescending
+	return q
+}
+
+// RangeValues implements Ranger for Quad[K1, K2, K3, K4].
+// If start and end are set, prefix must be set too or the function call will panic.
+// The bounds apply to K2, so all the keys sharing the same K2 are included or excluded together.
+func (q QuadRange[K1, K2, K3, K4]) RangeValues() (prefix *Quad[K1, K2, K3, K4], start *Bound[Quad[K1, K2, K3, K4]], end *Bound[Quad[K1, K2, K3, K4]], order Order) {
+	if (q.end != nil || q.start != nil) && q.prefix == nil {
+		panic("invalid QuadRange usage: if end or start are set, prefix must be set too")
+	}
+	if q.prefix != nil {
+		prefix = &Quad[K1, K2, K3, K4]{k1: q.prefix}
+	}
+	if q.start != nil {
+		start = &Bound[Quad[K1, K2, K3, K4]]{
+			value:     Quad[K1, K2, K3, K4]{k2: &q.start.value},
+			inclusive: q.start.inclusive,
+			partial:   true,
+		}
+	}
+	if q.end != nil {
+		end = &Bound[Quad[K1, K2, K3, K4]]{
+			value:     Quad[K1, K2, K3, K4]{k2: &q.end.value},
+			inclusive: q.end.inclusive,
+			partial:   true,
+		}
+	}
+	order = q.order
+	return
+}
+
+// QuadSuperPrefixRange implements the Ranger interface
+// to range over Quad keys prefixed by K1 and K2, and bounded over K3.
+// It is created using QuadRange.SuperPrefix.
+type QuadSuperPrefixRange[K1, K2, K3, K4 any] struct {
+	k1    K1
+	k2    K2
+	start *Bound[K3]
+	end   *Bound[K3]
+	order Order
+}
+
+// StartInclusive makes the range contain only keys whose K3 is bigger or equal to the provided start K3.
+func (q QuadSuperPrefixRange[K1, K2, K3, K4]) StartInclusive(start K3) QuadSuperPrefixRange[K1, K2, K3, K4] {
+	q.start = BoundInclusive(start)
+	return q
+}
+
+// StartExclusive makes the range contain only keys whose K3 is bigger than the provided start K3.
+func (q QuadSuperPrefixRange[K1, K2, K3, K4]) StartExclusive(start K3) QuadSuperPrefixRange[K1, K2, K3, K4] {
+	q.start = BoundExclusive(start)
+	return q
+}
+
+// EndInclusive makes the range contain only keys whose K3 is smaller or equal to the provided end K3.
+func (q QuadSuperPrefixRange[K1, K2, K3, K4]) EndInclusive(end K3) QuadSuperPrefixRange[K1, K2, K3, K4] {
+	q.end = BoundInclusive(end)
+	return q
+}
+
+// EndExclusive makes the range contain only keys whose K3 is smaller than the provided end K3.
+func (q QuadSuperPrefixRange[K1, K2, K3, K4]) EndExclusive(end K3) QuadSuperPrefixRange[K1, K2, K3, K4] {
+	q.end = BoundExclusive(end)
+	return q
+}
+
+// Descending makes the range run in reverse (bigger->smaller, instead of smaller->bigger)
+func (q QuadSuperPrefixRange[K1, K2, K3, K4]) Descending() QuadSuperPrefixRange[K1, K2, K3, K4] {
+	q.order = OrderDescending
+	return q
+}
+
+// RangeValues implements Ranger for Quad[K1, K2, K3, K4].
+// The range prefixes over K1 and K2, and the bounds apply to K3,
+// so all the keys sharing the same K3 are included or excluded together.
+func (q QuadSuperPrefixRange[K1, K2, K3, K4]) RangeValues() (prefix *Quad[K1, K2, K3, K4], start *Bound[Quad[K1, K2, K3, K4]], end *Bound[Quad[K1, K2, K3, K4]], order Order) {
+	p := QuadSuperPrefix[K1, K2, K3, K4](q.k1, q.k2)
+	prefix = &p
+	if q.start != nil {
+		start = &Bound[Quad[K1, K2, K3, K4]]{
+			value:     Quad[K1, K2, K3, K4]{k3: &q.start.value},
+			inclusive: q.start.inclusive,
+			partial:   true,
+		}
+	}
+	if q.end != nil {
+		end = &Bound[Quad[K1, K2, K3, K4]]{
+			value:     Quad[K1, K2, K3, K4]{k3: &q.end.value},
+			inclusive: q.end.inclusive,
+			partial:   true,
+		}
+	}
+	order = q.order
+	return
+}
+
+// QuadSuperPrefix3Range implements the Ranger interface
+// to range over Quad keys prefixed by K1, K2 and K3, and bounded over K4.
+// It is created using QuadRange.SuperPrefix3.
+type QuadSuperPrefix3Range[K1, K2, K3, K4 any] struct {
+	k1    K1
+	k2    K2
+	k3    K3
+	start *Bound[K4]
+	end   *Bound[K4]
+	order Order
+}
+
+// StartInclusive makes the range contain only keys which are bigger or equal to the provided start K4.
+func (q QuadSuperPrefix3Range[K1, K2, K3, K4]) StartInclusive(start K4) QuadSuperPrefix3Range[K1, K2, K3, K4] {
+	q.start = BoundInclusive(start)
+	return q
+}
+
+// StartExclusive makes the range contain only keys which are bigger to the provided start K4.
+func (q QuadSuperPrefix3Range[K1, K2, K3, K4]) StartExclusive(start K4) QuadSuperPrefix3Range[K1, K2, K3, K4] {
+	q.start = BoundExclusive(start)
+	return q
+}
+
+// EndInclusive makes the range contain only keys which are smaller or equal to the provided end K4.
+func (q QuadSuperPrefix3Range[K1, K2, K3, K4]) EndInclusive(end K4) QuadSuperPrefix3Range[K1, K2, K3, K4] {
+	q.end = BoundInclusive(end)
+	return q
+}
+
+// EndExclusive makes the range contain only keys which are smaller to the provided end K4.
+func (q QuadSuperPrefix3Range[K1, K2, K3, K4]) EndExclusive(end K4) QuadSuperPrefix3Range[K1, K2, K3, K4] {
+	q.end = BoundExclusive(end)
+	return q
+}
+
+// Descending makes the range run in reverse (bigger->smaller, instead of smaller->bigger)
+func (q QuadSuperPrefix3Range[K1, K2, K3, K4]) Descending() QuadSuperPrefix3Range[K1, K2, K3, K4] {
+	q.order = OrderDescending
+	return q
+}
+
+// RangeValues implements Ranger for Quad[K1, K2, K3, K4].
+// The range prefixes over K1, K2 and K3, and goes from K4 start to K4 end (if any are defined).
+func (q QuadSuperPrefix3Range[K1, K2, K3, K4]) RangeValues() (prefix *Quad[K1, K2, K3, K4], start *Bound[Quad[K1, K2, K3, K4]], end *Bound[Quad[K1, K2, K3, K4]], order Order) {
+	p := QuadSuperPrefix3[K1, K2, K3, K4](q.k1, q.k2, q.k3)
+	prefix = &p
+	if q.start != nil {
+		start = &Bound[Quad[K1, K2, K3, K4]]{
+			value:     Quad[K1, K2, K3, K4]{k4: &q.start.value},
+			inclusive: q.start.inclusive,
+		}
+	}
+	if q.end != nil {
+		end = &Bound[Quad[K1, K2, K3, K4]]{
+			value:     Quad[K1, K2, K3, K4]{k4: &q.end.value},
+			inclusive: q.end.inclusive,
+		}
+	}
+	order = q.order
+	return
+}
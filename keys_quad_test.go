@@ -0,0 +1,74 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuadKeyEncoder(t *testing.T) {
+	enc := QuadKeyEncoder(StringKeyEncoder, StringKeyEncoder, Uint64KeyEncoder, BoolKeyEncoder)
+
+	t.Run("bijectivity", func(t *testing.T) {
+		assertBijective(t, enc, Join4("k1", "k2", uint64(3), true))
+	})
+
+	t.Run("encode partial", func(t *testing.T) {
+		require.Equal(t, StringKeyEncoder.Encode("k1"), enc.Encode(QuadPrefix[string, string, uint64, bool]("k1")))
+		require.Equal(t,
+			append(append(StringKeyEncoder.Encode("k1"), StringKeyEncoder.Encode("k2")...), Uint64KeyEncoder.Encode(3)...),
+			enc.Encode(QuadSuperPrefix3[string, string, uint64, bool]("k1", "k2", 3)),
+		)
+	})
+
+	t.Run("empty panics", func(t *testing.T) {
+		require.Panics(t, func() {
+			enc.Encode(Quad[string, string, uint64, bool]{})
+		})
+	})
+
+	t.Run("stringify", func(t *testing.T) {
+		require.Equal(t, `("k1", "k2", "3", "true")`, enc.Stringify(Join4("k1", "k2", uint64(3), true)))
+		require.Equal(t, `("k1", "k2", <nil>, <nil>)`, enc.Stringify(QuadSuperPrefix[string, string, uint64, bool]("k1", "k2")))
+	})
+}
+
+func TestQuadRange(t *testing.T) {
+	sk, ctx, _ := deps()
+
+	ks := NewKeySet[Quad[string, string, uint64, bool]](
+		sk,
+		0,
+		QuadKeyEncoder(StringKeyEncoder, StringKeyEncoder, Uint64KeyEncoder, BoolKeyEncoder),
+	)
+	items := []Quad[string, string, uint64, bool]{
+		Join4("a", "x", uint64(0), false),
+		Join4("a", "y", uint64(0), false),
+		Join4("a", "y", uint64(1), false),
+		Join4("a", "y", uint64(1), true),
+		Join4("a", "y", uint64(2), false),
+		Join4("a", "z", uint64(0), true),
+		Join4("b", "x", uint64(0), false),
+	}
+	for _, i := range items {
+		ks.Insert(ctx, i)
+	}
+
+	type quad = Quad[string, string, uint64, bool]
+
+	results := ks.Iterate(ctx, QuadRange[string, string, uint64, bool]{}.Prefix("a").StartExclusive("x").EndExclusive("z")).Keys()
+	require.Equal(t, items[1:5], results)
+
+	results = ks.Iterate(ctx, QuadRange[string, string, uint64, bool]{}.SuperPrefix("a", "y").StartInclusive(1).EndInclusive(1)).Keys()
+	require.Equal(t, items[2:4], results)
+
+	results = ks.Iterate(ctx, QuadRange[string, string, uint64, bool]{}.SuperPrefix3("a", "y", 1).StartExclusive(false)).Keys()
+	require.Equal(t, []quad{items[3]}, results)
+
+	results = ks.Iterate(ctx, QuadRange[string, string, uint64, bool]{}.Descending().SuperPrefix3("a", "y", 1)).Keys()
+	require.Equal(t, []quad{items[3], items[2]}, results)
+
+	require.Panics(t, func() {
+		QuadRange[string, string, uint64, bool]{}.StartInclusive("x").RangeValues()
+	})
+}
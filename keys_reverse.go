@@ -0,0 +1,80 @@
+package collections
+
+// ReverseKeyEncoder wraps the provided KeyEncoder and inverts the byte ordering of the keys
+// it produces, by complementing every byte. It can be used to mix ascending and descending
+// parts inside a composite key, for example to iterate over the most recent snapshots first
+// for each prefix:
+//
+//	PairKeyEncoder(StringKeyEncoder, ReverseKeyEncoder(TimeUnixNanoKeyEncoder))
+//
+// Ranges keep working in the encoded key order, so a start bound of a reversed
+// key includes the keys which are smaller or equal to it, and iterating in
+// OrderAscending returns the biggest keys first.
+//
+// The inner KeyEncoder must be self-delimiting, meaning that no encoded key
+// can be the prefix of another encoded key, this is the case of fixed length
+// encoders (ex: Uint64KeyEncoder, TimeUnixNanoKeyEncoder) or terminated encoders
+// (ex: StringKeyEncoder). Encoders whose Decode consumes the whole buffer, like
+// TimeKeyEncoder, are not supported.
+func ReverseKeyEncoder[K any](kc KeyEncoder[K]) KeyEncoder[K] {
+	r := reverseKeyEncoder[K]{kc: kc}
+	if sized, ok := kc.(SizedKeyEncoder[K]); ok {
+		return sizedReverseKeyEncoder[K]{reverseKeyEncoder: r, sized: sized}
+	}
+	return r
+}
+
+type reverseKeyEncoder[K any] struct {
+	kc KeyEncoder[K]
+}
+
+func (r reverseKeyEncoder[K]) Stringify(key K) string { return r.kc.Stringify(key) }
+
+func (r reverseKeyEncoder[K]) Encode(key K) []byte {
+	// the inner encoder might return bytes it still references, so we copy them.
+	encoded := r.kc.Encode(key)
+	b := make([]byte, len(encoded))
+	copy(b, encoded)
+	complementBytes(b)
+	return b
+}
+
+// Decode complements the bytes before passing them to the inner KeyEncoder.
+// Since the length of the key is not known until it is decoded, the whole
+// buffer is copied and complemented.
+func (r reverseKeyEncoder[K]) Decode(b []byte) (int, K) {
+	inverted := make([]byte, len(b))
+	copy(inverted, b)
+	complementBytes(inverted)
+	return r.kc.Decode(inverted)
+}
+
+// decodeCopies is always true, as the inner KeyEncoder decodes a copy of the buffer.
+func (reverseKeyEncoder[K]) decodeCopies() bool { return true }
+
+// sizedReverseKeyEncoder is the reverseKeyEncoder
+// of an inner SizedKeyEncoder.
+type sizedReverseKeyEncoder[K any] struct {
+	reverseKeyEncoder[K]
+	sized SizedKeyEncoder[K]
+}
+
+// Encode encodes the key with a single allocation.
+func (r sizedReverseKeyEncoder[K]) Encode(key K) []byte {
+	buf := make([]byte, r.Size(key))
+	return buf[:r.PutKey(buf, key)]
+}
+
+func (r sizedReverseKeyEncoder[K]) Size(key K) int { return r.sized.Size(key) }
+
+func (r sizedReverseKeyEncoder[K]) PutKey(buf []byte, key K) int {
+	n := r.sized.PutKey(buf, key)
+	complementBytes(buf[:n])
+	return n
+}
+
+func complementBytes(b []byte) {
+	for i := range b {
+		b[i] = ^b[i]
+	}
+}
@@ -0,0 +1,59 @@
+package collections
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseKeyEncoder(t *testing.T) {
+	t.Run("bijectivity", func(t *testing.T) {
+		assertBijective(t, ReverseKeyEncoder(Uint64KeyEncoder), uint64(10))
+		assertBijective(t, ReverseKeyEncoder(StringKeyEncoder), "reversed")
+		assertBijective(t, PairKeyEncoder(ReverseKeyEncoder(StringKeyEncoder), Uint64KeyEncoder), Join("k1", uint64(2)))
+	})
+
+	t.Run("reverse ordering", func(t *testing.T) {
+		assertOrdered(t, ReverseKeyEncoder(Int64KeyEncoder), []int64{100, 1, 0, -1, -100})
+		assertOrdered(t, ReverseKeyEncoder(StringKeyEncoder), []string{"b", "ab", "aa", "a", ""})
+	})
+
+	t.Run("stringify", func(t *testing.T) {
+		require.Equal(t, "10", ReverseKeyEncoder(Uint64KeyEncoder).Stringify(10))
+	})
+}
+
+func TestReverseKeyEncoderRange(t *testing.T) {
+	sk, ctx, _ := deps()
+
+	ks := NewKeySet[Pair[string, time.Time]](sk, 0,
+		PairKeyEncoder(StringKeyEncoder, ReverseKeyEncoder(TimeUnixNanoKeyEncoder)),
+	)
+	snapshot := func(pair string, sec int64) Pair[string, time.Time] {
+		return Join(pair, time.Unix(sec, 0).UTC())
+	}
+	for _, sec := range []int64{1, 2, 3, 4} {
+		ks.Insert(ctx, snapshot("ubtc:unusd", sec))
+		ks.Insert(ctx, snapshot("ueth:unusd", sec))
+	}
+
+	// newest first per prefix
+	results := ks.Iterate(ctx, PairRange[string, time.Time]{}.Prefix("ubtc:unusd")).Keys()
+	require.Equal(t, []Pair[string, time.Time]{
+		snapshot("ubtc:unusd", 4), snapshot("ubtc:unusd", 3), snapshot("ubtc:unusd", 2), snapshot("ubtc:unusd", 1),
+	}, results)
+
+	// the snapshots at or before 3, newest first.
+	rng := PairRange[string, time.Time]{}.Prefix("ueth:unusd").StartInclusive(time.Unix(3, 0).UTC())
+	results = ks.Iterate(ctx, rng).Keys()
+	require.Equal(t, []Pair[string, time.Time]{
+		snapshot("ueth:unusd", 3), snapshot("ueth:unusd", 2), snapshot("ueth:unusd", 1),
+	}, results)
+
+	// oldest first using descending order
+	results = ks.Iterate(ctx, rng.EndExclusive(time.Unix(1, 0).UTC()).Descending()).Keys()
+	require.Equal(t, []Pair[string, time.Time]{
+		snapshot("ueth:unusd", 2), snapshot("ueth:unusd", 3),
+	}, results)
+}
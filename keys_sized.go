@@ -0,0 +1,28 @@
+package collections
+
+// encodeKey encodes the key with a single allocation
+// if the KeyEncoder is a SizedKeyEncoder.
+func encodeKey[K any](kc KeyEncoder[K], key K) []byte {
+	sized, ok := kc.(SizedKeyEncoder[K])
+	if !ok {
+		return kc.Encode(key)
+	}
+	buf := make([]byte, sized.Size(key))
+	return buf[:sized.PutKey(buf, key)]
+}
+
+// sizePart returns the size of an optional part of a composite key.
+func sizePart[K any](kc SizedKeyEncoder[K], key *K) int {
+	if key == nil {
+		return 0
+	}
+	return kc.Size(*key)
+}
+
+// putPart writes an optional part of a composite key into the buffer.
+func putPart[K any](kc SizedKeyEncoder[K], buf []byte, key *K) int {
+	if key == nil {
+		return 0
+	}
+	return kc.PutKey(buf, *key)
+}
@@ -0,0 +1,163 @@
+package collections
+
+import (
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+)
+
+// isSized reports whether the KeyEncoder is a SizedKeyEncoder.
+func isSized[K any](kc KeyEncoder[K]) bool {
+	_, ok := kc.(SizedKeyEncoder[K])
+	return ok
+}
+
+// assertSized asserts that the KeyEncoder is a SizedKeyEncoder,
+// whose encoding matches the expected one.
+func assertSized[T any](t *testing.T, kc KeyEncoder[T], key T, expected []byte) {
+	t.Helper()
+	sized, ok := kc.(SizedKeyEncoder[T])
+	require.True(t, ok, "%T is not a SizedKeyEncoder", kc)
+	require.Equal(t, len(expected), sized.Size(key))
+	// the buffer can be bigger than needed
+	buf := make([]byte, sized.Size(key)+4)
+	n := sized.PutKey(buf, key)
+	require.Equal(t, expected, buf[:n])
+	require.Equal(t, expected, encodeKey(kc, key))
+	require.Equal(t, expected, kc.Encode(key))
+}
+
+func TestSizedKeyEncoders(t *testing.T) {
+	now := time.Unix(0, 1_700_000_000_000_000_000).UTC()
+	addr := sdk.AccAddress("address")
+
+	assertSized(t, StringKeyEncoder, "hello", stringKey{}.Encode("hello"))
+	assertSized(t, TerminalStringKeyEncoder, "hello", []byte("hello"))
+	assertSized(t, Uint64KeyEncoder, 1<<40, uint64Key{}.Encode(1<<40))
+	assertSized(t, Uint32KeyEncoder, 1<<20, uint32Key{}.Encode(1<<20))
+	assertSized(t, Uint16KeyEncoder, 1<<10, uint16Key{}.Encode(1<<10))
+	assertSized(t, Uint8KeyEncoder, 7, uint8Key{}.Encode(7))
+	assertSized(t, BoolKeyEncoder, true, boolKey{}.Encode(true))
+	assertSized(t, BoolKeyEncoder, false, boolKey{}.Encode(false))
+	assertSized(t, Int64KeyEncoder, -1<<40, int64Key{}.Encode(-1<<40))
+	assertSized(t, Int32KeyEncoder, -1<<20, int32Key{}.Encode(-1<<20))
+	assertSized(t, Int16KeyEncoder, -1<<10, int16Key{}.Encode(-1<<10))
+	assertSized(t, Int8KeyEncoder, -7, int8Key{}.Encode(-7))
+	assertSized(t, TimeUnixNanoKeyEncoder, now, timeUnixNanoKey{}.Encode(now))
+	assertSized(t, AccAddressBytesKeyEncoder, addr, addressBytesKey[sdk.AccAddress]{}.Encode(addr))
+	assertSized(t, BytesKeyEncoder, []byte("bytes"), bytesKey{}.Encode([]byte("bytes")))
+	assertSized(t, TerminalBytesKeyEncoder, []byte("bytes"), []byte("bytes"))
+	assertSized(t, FixedBytesKeyEncoder[[8]byte](), [8]byte{1, 2, 3}, fixedBytesKey[[8]byte]{}.Encode([8]byte{1, 2, 3}))
+
+	assertSized(t, TimeKeyEncoder, now, timeKey{}.Encode(now))
+	for _, v := range []math.Int{math.ZeroInt(), math.NewInt(255), math.NewIntFromUint64(1 << 63).MulRaw(1 << 40)} {
+		assertSized(t, IntKeyEncoder, v, intKeyEncoder{}.Encode(v))
+	}
+	for _, v := range []math.Int{math.NewInt(-1 << 40), math.ZeroInt(), math.NewInt(1 << 40)} {
+		assertSized(t, SignedIntKeyEncoder, v, signedIntKeyEncoder{}.Encode(v))
+	}
+	for _, v := range []math.LegacyDec{math.LegacyNewDec(-1000), math.LegacyZeroDec(), math.LegacyNewDecWithPrec(1, 18), math.LegacyNewDec(1000)} {
+		assertSized(t, DecKeyEncoder, v, decKeyEncoder{}.Encode(v))
+	}
+	assertSized(t, FromUpstreamKeyCodec(sdk.AccAddressKey), addr, append([]byte(nil), addr...))
+	assertSized(t, FromUpstreamNonTerminalKeyCodec(sdk.AccAddressKey), addr, AccAddressBytesKeyEncoder.Encode(addr))
+
+	enum := EnumKeyEncoder[stakingtypes.BondStatus](stakingtypes.BondStatus_name)
+	for _, v := range []stakingtypes.BondStatus{-300, -1, 0, 3, 253, 254, 1 << 20} {
+		assertSized(t, enum, v, enumKey[stakingtypes.BondStatus]{}.Encode(v))
+	}
+
+	pair := PairKeyEncoder(StringKeyEncoder, Uint64KeyEncoder)
+	plainPair := pairKeyEncoder[string, uint64]{kc1: StringKeyEncoder, kc2: Uint64KeyEncoder}
+	assertSized(t, pair, Join("a", uint64(1)), plainPair.Encode(Join("a", uint64(1))))
+	assertSized(t, pair, PairPrefix[string, uint64]("a"), plainPair.Encode(PairPrefix[string, uint64]("a")))
+	assertSized(t, pair, PairSuffix[string, uint64](1), plainPair.Encode(PairSuffix[string, uint64](1)))
+	require.Panics(t, func() { pair.(SizedKeyEncoder[Pair[string, uint64]]).Size(Pair[string, uint64]{}) })
+
+	triple := TripleKeyEncoder(StringKeyEncoder, Uint64KeyEncoder, BoolKeyEncoder)
+	plainTriple := tripleKeyEncoder[string, uint64, bool]{kc1: StringKeyEncoder, kc2: Uint64KeyEncoder, kc3: BoolKeyEncoder}
+	assertSized(t, triple, Join3("a", uint64(1), true), plainTriple.Encode(Join3("a", uint64(1), true)))
+	assertSized(t, triple, TripleSuperPrefix[string, uint64, bool]("a", 1), plainTriple.Encode(TripleSuperPrefix[string, uint64, bool]("a", 1)))
+
+	quad := QuadKeyEncoder(StringKeyEncoder, Uint64KeyEncoder, BoolKeyEncoder, Int8KeyEncoder)
+	plainQuad := quadKeyEncoder[string, uint64, bool, int8]{kc1: StringKeyEncoder, kc2: Uint64KeyEncoder, kc3: BoolKeyEncoder, kc4: Int8KeyEncoder}
+	assertSized(t, quad, Join4("a", uint64(1), true, int8(-1)), plainQuad.Encode(Join4("a", uint64(1), true, int8(-1))))
+	assertSized(t, quad, QuadPrefix[string, uint64, bool, int8]("a"), plainQuad.Encode(QuadPrefix[string, uint64, bool, int8]("a")))
+
+	reverse := ReverseKeyEncoder(Uint64KeyEncoder)
+	assertSized(t, reverse, 10, reverseKeyEncoder[uint64]{kc: Uint64KeyEncoder}.Encode(10))
+	nested := PairKeyEncoder(StringKeyEncoder, ReverseKeyEncoder(TimeUnixNanoKeyEncoder))
+	require.True(t, isSized(nested))
+	assertBijective(t, nested, Join("a", now))
+}
+
+func TestNotSizedKeyEncoders(t *testing.T) {
+	// encoders kept for state compatibility are not sized,
+	// and neither are the composite keys using them.
+	require.False(t, isSized(AccAddressKeyEncoder))
+	require.False(t, isSized(ValAddressKeyEncoder))
+	require.False(t, isSized(ConsAddressKeyEncoder))
+	require.False(t, isSized(SdkDecKeyEncoder))
+	pair := PairKeyEncoder(AccAddressKeyEncoder, Uint64KeyEncoder)
+	require.False(t, isSized(pair))
+	require.False(t, isSized(ReverseKeyEncoder(SdkDecKeyEncoder)))
+	require.True(t, isSized(PairKeyEncoder(IntKeyEncoder, TimeKeyEncoder)))
+
+	addr := sdk.AccAddress("address")
+	require.Equal(t, pair.Encode(Join(addr, uint64(1))), encodeKey(pair, Join(addr, uint64(1))))
+}
+
+func TestSizedKeyEncodersAllocs(t *testing.T) {
+	allocs := func(encode func()) float64 { return testing.AllocsPerRun(100, encode) }
+
+	pair := PairKeyEncoder(StringKeyEncoder, Uint64KeyEncoder)
+	pairKey := Join("denom", uint64(1))
+	require.Equal(t, 1.0, allocs(func() { pair.Encode(pairKey) }))
+
+	triple := TripleKeyEncoder(StringKeyEncoder, Uint64KeyEncoder, BoolKeyEncoder)
+	tripleKey := Join3("denom", uint64(1), true)
+	require.Equal(t, 1.0, allocs(func() { triple.Encode(tripleKey) }))
+
+	quad := QuadKeyEncoder(StringKeyEncoder, Uint64KeyEncoder, BoolKeyEncoder, Int8KeyEncoder)
+	quadKey := Join4("denom", uint64(1), true, int8(-1))
+	require.Equal(t, 1.0, allocs(func() { quad.Encode(quadKey) }))
+
+	reverse := PairKeyEncoder(StringKeyEncoder, ReverseKeyEncoder(Uint64KeyEncoder))
+	require.Equal(t, 1.0, allocs(func() { reverse.Encode(pairKey) }))
+}
+
+func BenchmarkPairKeyEncode(b *testing.B) {
+	kc := PairKeyEncoder(StringKeyEncoder, Uint64KeyEncoder)
+	plain := pairKeyEncoder[string, uint64]{kc1: StringKeyEncoder, kc2: Uint64KeyEncoder}
+	key := Join("denom", uint64(1))
+
+	b.Run("sized", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = kc.Encode(key)
+		}
+	})
+	b.Run("plain", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = plain.Encode(key)
+		}
+	})
+}
+
+func BenchmarkMultiIndexInsert(b *testing.B) {
+	sk, ctx, _ := deps()
+	index := NewMultiIndex[string, uint64, person](sk, 0, StringKeyEncoder, Uint64KeyEncoder,
+		func(v person) string { return v.City })
+	p := person{ID: 1, City: "milan"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index.Insert(ctx, p.ID, p)
+	}
+}
@@ -2,6 +2,7 @@ package collections
 
 import (
 	"bytes"
+	stdmath "math"
 	"sort"
 	"testing"
 	"time"
@@ -24,6 +25,80 @@ func TestUint64(t *testing.T) {
 	})
 }
 
+func TestBoolKey(t *testing.T) {
+	assertBijective(t, BoolKeyEncoder, true)
+	assertBijective(t, BoolKeyEncoder, false)
+	assertOrdered(t, BoolKeyEncoder, []bool{false, true})
+	assertBijective(t, PairKeyEncoder(BoolKeyEncoder, StringKeyEncoder), Join(true, "active"))
+	require.Panics(t, func() { BoolKeyEncoder.Decode([]byte{}) })
+	require.Panics(t, func() { BoolKeyEncoder.Decode([]byte{2}) })
+}
+
+func TestFixedWidthUintKeys(t *testing.T) {
+	t.Run("bijectivity", func(t *testing.T) {
+		assertBijective(t, Uint32KeyEncoder, uint32(0x01234567))
+		assertBijective(t, Uint16KeyEncoder, uint16(0x0123))
+		assertBijective(t, Uint8KeyEncoder, uint8(0x01))
+	})
+
+	t.Run("fixed length", func(t *testing.T) {
+		require.Equal(t, []byte{0, 0, 1, 0}, Uint32KeyEncoder.Encode(256))
+		require.Equal(t, []byte{1, 0}, Uint16KeyEncoder.Encode(256))
+		require.Equal(t, []byte{255}, Uint8KeyEncoder.Encode(255))
+	})
+
+	t.Run("proper ordering", func(t *testing.T) {
+		assertOrdered(t, Uint32KeyEncoder, []uint32{0, 1, 255, 256, stdmath.MaxUint32})
+		assertOrdered(t, Uint16KeyEncoder, []uint16{0, 1, 255, 256, stdmath.MaxUint16})
+		assertOrdered(t, Uint8KeyEncoder, []uint8{0, 1, 127, 128, stdmath.MaxUint8})
+	})
+
+	t.Run("pair prefix", func(t *testing.T) {
+		// fixed width keys must only consume their own bytes
+		// so that they can be used as the first part of a Pair.
+		assertBijective(t, PairKeyEncoder(Uint32KeyEncoder, StringKeyEncoder), Join(uint32(7), "seven"))
+		assertBijective(t, PairKeyEncoder(Uint16KeyEncoder, StringKeyEncoder), Join(uint16(7), "seven"))
+		assertBijective(t, PairKeyEncoder(Uint8KeyEncoder, StringKeyEncoder), Join(uint8(7), "seven"))
+	})
+
+	t.Run("panics on short bytes", func(t *testing.T) {
+		require.Panics(t, func() { Uint32KeyEncoder.Decode([]byte{0x1}) })
+		require.Panics(t, func() { Uint16KeyEncoder.Decode([]byte{0x1}) })
+		require.Panics(t, func() { Uint8KeyEncoder.Decode([]byte{}) })
+	})
+}
+
+func TestSignedIntKeys(t *testing.T) {
+	t.Run("bijectivity", func(t *testing.T) {
+		for _, k := range []int64{0, 1, -1, 1 << 40, -(1 << 40), stdmath.MaxInt64, stdmath.MinInt64} {
+			assertBijective(t, Int64KeyEncoder, k)
+		}
+		for _, k := range []int32{0, 1, -1, stdmath.MaxInt32, stdmath.MinInt32} {
+			assertBijective(t, Int32KeyEncoder, k)
+		}
+		for _, k := range []int16{0, 1, -1, stdmath.MaxInt16, stdmath.MinInt16} {
+			assertBijective(t, Int16KeyEncoder, k)
+		}
+		for _, k := range []int8{0, 1, -1, stdmath.MaxInt8, stdmath.MinInt8} {
+			assertBijective(t, Int8KeyEncoder, k)
+		}
+	})
+
+	t.Run("proper ordering", func(t *testing.T) {
+		assertOrdered(t, Int64KeyEncoder, []int64{stdmath.MinInt64, -(1 << 40), -256, -1, 0, 1, 255, 1 << 40, stdmath.MaxInt64})
+		assertOrdered(t, Int32KeyEncoder, []int32{stdmath.MinInt32, -256, -1, 0, 1, 255, stdmath.MaxInt32})
+		assertOrdered(t, Int16KeyEncoder, []int16{stdmath.MinInt16, -256, -1, 0, 1, 255, stdmath.MaxInt16})
+		assertOrdered(t, Int8KeyEncoder, []int8{stdmath.MinInt8, -1, 0, 1, stdmath.MaxInt8})
+	})
+
+	t.Run("panics on short bytes", func(t *testing.T) {
+		require.Panics(t, func() { Int64KeyEncoder.Decode([]byte{0x1}) })
+		require.Panics(t, func() { Int32KeyEncoder.Decode([]byte{0x1}) })
+		require.Panics(t, func() { Int16KeyEncoder.Decode([]byte{0x1}) })
+		require.Panics(t, func() { Int8KeyEncoder.Decode([]byte{}) })
+	})
+}
+
 func TestStringKey(t *testing.T) {
 	t.Run("bijective", func(t *testing.T) {
 		assertBijective[string](t, StringKeyEncoder, "test")
@@ -84,6 +159,34 @@ func TestAccAddressKey(t *testing.T) {
 	})
 }
 
+func TestAddressBytesKeys(t *testing.T) {
+	addr := secp256k1.GenPrivKey().PubKey().Address()
+
+	t.Run("bijective", func(t *testing.T) {
+		assertBijective(t, AccAddressBytesKeyEncoder, sdk.AccAddress(addr))
+		assertBijective(t, ValAddressBytesKeyEncoder, sdk.ValAddress(addr))
+		assertBijective(t, ConsAddressBytesKeyEncoder, sdk.ConsAddress(addr))
+		// 32 bytes module addresses
+		assertBijective(t, AccAddressBytesKeyEncoder, sdk.AccAddress(bytes.Repeat([]byte{0xFF}, 32)))
+	})
+
+	t.Run("encoding", func(t *testing.T) {
+		require.Equal(t, append([]byte{byte(len(addr))}, addr...), AccAddressBytesKeyEncoder.Encode(sdk.AccAddress(addr)))
+		require.Equal(t, sdk.AccAddress(addr).String(), AccAddressBytesKeyEncoder.Stringify(sdk.AccAddress(addr)))
+	})
+
+	t.Run("pair prefix", func(t *testing.T) {
+		enc := PairKeyEncoder(AccAddressBytesKeyEncoder, ValAddressBytesKeyEncoder)
+		assertBijective(t, enc, Join(sdk.AccAddress(addr), sdk.ValAddress(addr)))
+	})
+
+	t.Run("panics", func(t *testing.T) {
+		require.Panics(t, func() { AccAddressBytesKeyEncoder.Encode(make(sdk.AccAddress, 256)) })
+		require.Panics(t, func() { AccAddressBytesKeyEncoder.Decode([]byte{}) })
+		require.Panics(t, func() { AccAddressBytesKeyEncoder.Decode([]byte{2, 1}) })
+	})
+}
+
 func TestTimeKey(t *testing.T) {
 	t.Run("bijective", func(t *testing.T) {
 		key := time.Now()
@@ -91,6 +194,36 @@ func TestTimeKey(t *testing.T) {
 	})
 }
 
+func TestTimeUnixNanoKey(t *testing.T) {
+	t.Run("bijective", func(t *testing.T) {
+		assertBijective(t, TimeUnixNanoKeyEncoder, time.Now().Round(0).UTC())
+		assertBijective(t, TimeUnixNanoKeyEncoder, time.Date(1969, 7, 20, 20, 17, 0, 1, time.UTC))
+		assertBijective(t, TimeUnixNanoKeyEncoder, time.Unix(0, 0).UTC())
+	})
+
+	t.Run("proper ordering", func(t *testing.T) {
+		assertOrdered(t, TimeUnixNanoKeyEncoder, []time.Time{
+			minUnixNanoTime,
+			time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Unix(0, -1),
+			time.Unix(0, 0),
+			time.Unix(0, 1),
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			maxUnixNanoTime,
+		})
+	})
+
+	t.Run("pair prefix", func(t *testing.T) {
+		enc := PairKeyEncoder(TimeUnixNanoKeyEncoder, StringKeyEncoder)
+		assertBijective(t, enc, Join(time.Unix(1_700_000_000, 5).UTC(), "twap"))
+	})
+
+	t.Run("panics out of range", func(t *testing.T) {
+		require.Panics(t, func() { TimeUnixNanoKeyEncoder.Encode(time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC)) })
+		require.Panics(t, func() { TimeUnixNanoKeyEncoder.Encode(time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC)) })
+	})
+}
+
 func TestValAddressKey(t *testing.T) {
 	t.Run("bijective", func(t *testing.T) {
 		assertBijective(t, ValAddressKeyEncoder, sdk.ValAddress(secp256k1.GenPrivKey().PubKey().Address()))
@@ -106,3 +239,45 @@ func TestSdkDecKey(t *testing.T) {
 		assertBijective(t, SdkDecKeyEncoder, math.LegacyZeroDec())
 	})
 }
+
+func TestDecKey(t *testing.T) {
+	t.Run("bijective", func(t *testing.T) {
+		assertBijective(t, DecKeyEncoder, math.LegacyNewDec(123456789))
+		assertBijective(t, DecKeyEncoder, math.LegacyMustNewDecFromStr("-1000.5858"))
+		assertBijective(t, DecKeyEncoder, math.LegacySmallestDec())
+		assertBijective(t, DecKeyEncoder, math.LegacySmallestDec().Neg())
+	})
+
+	t.Run("zero dec", func(t *testing.T) {
+		assertBijective(t, DecKeyEncoder, math.LegacyZeroDec())
+	})
+
+	t.Run("proper ordering", func(t *testing.T) {
+		assertOrdered(t, DecKeyEncoder, []math.LegacyDec{
+			math.LegacyNewDec(-1_000_000_000),
+			math.LegacyMustNewDecFromStr("-256.5"),
+			math.LegacyMustNewDecFromStr("-256.25"),
+			math.LegacyNewDec(-1),
+			math.LegacySmallestDec().Neg(),
+			math.LegacyZeroDec(),
+			math.LegacySmallestDec(),
+			math.LegacyMustNewDecFromStr("0.5"),
+			math.LegacyNewDec(1),
+			math.LegacyMustNewDecFromStr("256.25"),
+			math.LegacyMustNewDecFromStr("256.5"),
+			math.LegacyNewDec(1_000_000_000),
+		})
+	})
+
+	t.Run("pair prefix", func(t *testing.T) {
+		enc := PairKeyEncoder(DecKeyEncoder, StringKeyEncoder)
+		assertBijective(t, enc, Join(math.LegacyMustNewDecFromStr("-1.5"), "order"))
+	})
+
+	t.Run("panics", func(t *testing.T) {
+		require.Panics(t, func() { DecKeyEncoder.Encode(math.LegacyDec{}) })
+		require.Panics(t, func() { DecKeyEncoder.Decode([]byte{}) })
+		require.Panics(t, func() { DecKeyEncoder.Decode([]byte{0x3}) })
+		require.Panics(t, func() { DecKeyEncoder.Decode([]byte{decKeyPositive, 2, 1}) })
+	})
+}
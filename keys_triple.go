@@ -0,0 +1,344 @@
+package collections
+
+import "strings"
+
+// TripleKeyEncoder creates a new KeyEncoder for Triple types, given the three key encoders for K1, K2 and K3.
+func TripleKeyEncoder[K1, K2, K3 any](kc1 KeyEncoder[K1], kc2 KeyEncoder[K2], kc3 KeyEncoder[K3]) KeyEncoder[Triple[K1, K2, K3]] {
+	t := tripleKeyEncoder[K1, K2, K3]{
+		kc1: kc1,
+		kc2: kc2,
+		kc3: kc3,
+	}
+	sk1, ok1 := kc1.(SizedKeyEncoder[K1])
+	sk2, ok2 := kc2.(SizedKeyEncoder[K2])
+	sk3, ok3 := kc3.(SizedKeyEncoder[K3])
+	if ok1 && ok2 && ok3 {
+		return sizedTripleKeyEncoder[K1, K2, K3]{tripleKeyEncoder: t, sk1: sk1, sk2: sk2, sk3: sk3}
+	}
+	return t
+}
+
+type tripleKeyEncoder[K1, K2, K3 any] struct {
+	kc1 KeyEncoder[K1]
+	kc2 KeyEncoder[K2]
+	kc3 KeyEncoder[K3]
+}
+
+// Stringify returns a string representation of the given Triple.
+func (t tripleKeyEncoder[K1, K2, K3]) Stringify(key Triple[K1, K2, K3]) string {
+	return stringifyComposite(
+		stringifyPart(t.kc1, key.k1),
+		stringifyPart(t.kc2, key.k2),
+		stringifyPart(t.kc3, key.k3),
+	)
+}
+
+// Encode encodes the Triple.
+// The byte versions of the present parts of the key are joined together in order.
+// Panics if no part is present.
+func (t tripleKeyEncoder[K1, K2, K3]) Encode(key Triple[K1, K2, K3]) []byte {
+	if key.k1 == nil && key.k2 == nil && key.k3 == nil {
+		panic("empty Triple key")
+	}
+	var b []byte
+	if key.k1 != nil {
+		b = append(b, t.kc1.Encode(*key.k1)...)
+	}
+	if key.k2 != nil {
+		b = append(b, t.kc2.Encode(*key.k2)...)
+	}
+	if key.k3 != nil {
+		b = append(b, t.kc3.Encode(*key.k3)...)
+	}
+	return b
+}
+
+func (t tripleKeyEncoder[K1, K2, K3]) decodeCopies() bool {
+	return decodeCopies(t.kc1) && decodeCopies(t.kc2) && decodeCopies(t.kc3)
+}
+
+// Decode decodes the Triple. It assumes that the provided bytes contain all the three parts.
+func (t tripleKeyEncoder[K1, K2, K3]) Decode(b []byte) (int, Triple[K1, K2, K3]) {
+	i1, k1 := t.kc1.Decode(b)
+	i2, k2 := t.kc2.Decode(b[i1:])
+	i3, k3 := t.kc3.Decode(b[i1+i2:])
+	return i1 + i2 + i3, Triple[K1, K2, K3]{
+		k1: &k1,
+		k2: &k2,
+		k3: &k3,
+	}
+}
+
+// sizedTripleKeyEncoder is the tripleKeyEncoder of parts which are SizedKeyEncoder,
+// which are kept to avoid asserting their type on every key.
+type sizedTripleKeyEncoder[K1, K2, K3 any] struct {
+	tripleKeyEncoder[K1, K2, K3]
+	sk1 SizedKeyEncoder[K1]
+	sk2 SizedKeyEncoder[K2]
+	sk3 SizedKeyEncoder[K3]
+}
+
+// Encode encodes the Triple with a single allocation.
+func (t sizedTripleKeyEncoder[K1, K2, K3]) Encode(key Triple[K1, K2, K3]) []byte {
+	buf := make([]byte, t.Size(key))
+	return buf[:t.PutKey(buf, key)]
+}
+
+// Size returns the size of the encoded Triple.
+func (t sizedTripleKeyEncoder[K1, K2, K3]) Size(key Triple[K1, K2, K3]) int {
+	if key.k1 == nil && key.k2 == nil && key.k3 == nil {
+		panic("empty Triple key")
+	}
+	return sizePart(t.sk1, key.k1) + sizePart(t.sk2, key.k2) + sizePart(t.sk3, key.k3)
+}
+
+// PutKey writes the present parts of the Triple into the buffer.
+func (t sizedTripleKeyEncoder[K1, K2, K3]) PutKey(buf []byte, key Triple[K1, K2, K3]) int {
+	if key.k1 == nil && key.k2 == nil && key.k3 == nil {
+		panic("empty Triple key")
+	}
+	n := putPart(t.sk1, buf, key.k1)
+	n += putPart(t.sk2, buf[n:], key.k2)
+	return n + putPart(t.sk3, buf[n:], key.k3)
+}
+
+// Join3 returns a fully populated Triple
+// given the three key parts.
+func Join3[K1, K2, K3 any](k1 K1, k2 K2, k3 K3) Triple[K1, K2, K3] {
+	return Triple[K1, K2, K3]{
+		k1: &k1,
+		k2: &k2,
+		k3: &k3,
+	}
+}
+
+// TriplePrefix returns a partially populated Triple
+// given the first part of the key.
+func TriplePrefix[K1, K2, K3 any](k1 K1) Triple[K1, K2, K3] {
+	return Triple[K1, K2, K3]{
+		k1: &k1,
+	}
+}
+
+// TripleSuperPrefix returns a partially populated Triple
+// given the first and the second part of the key.
+func TripleSuperPrefix[K1, K2, K3 any](k1 K1, k2 K2) Triple[K1, K2, K3] {
+	return Triple[K1, K2, K3]{
+		k1: &k1,
+		k2: &k2,
+	}
+}
+
+// Triple defines a storage key composed of three keys of different or equal types.
+type Triple[K1, K2, K3 any] struct {
+	k1 *K1
+	k2 *K2
+	k3 *K3
+}
+
+func (t Triple[K1, K2, K3]) K1() (k1 K1) {
+	if t.k1 != nil {
+		k1 = *t.k1
+	}
+	return
+}
+
+func (t Triple[K1, K2, K3]) K2() (k2 K2) {
+	if t.k2 != nil {
+		k2 = *t.k2
+	}
+	return
+}
+
+func (t Triple[K1, K2, K3]) K3() (k3 K3) {
+	if t.k3 != nil {
+		k3 = *t.k3
+	}
+	return
+}
+
+// TripleRange implements the Ranger interface
+// to provide an easier way to range over Triple keys.
+// The range can be prefixed over K1, and bounded over K2,
+// or prefixed over K1 and K2 using SuperPrefix.
+type TripleRange[K1, K2, K3 any] struct {
+	prefix *K1
+	start  *Bound[K2]
+	end    *Bound[K2]
+	order  Order
+}
+
+// Prefix makes the range contain only keys starting with the given k1 prefix.
+func (t TripleRange[K1, K2, K3]) Prefix(prefix K1) TripleRange[K1, K2, K3] {
+	t.prefix = &prefix
+	return t
+}
+
+// SuperPrefix returns a range which contains only keys starting with the given k1 and k2
+// prefixes, the returned range can be bounded over K3.
+func (t TripleRange[K1, K2, K3]) SuperPrefix(k1 K1, k2 K2) TripleSuperPrefixRange[K1, K2, K3] {
+	return TripleSuperPrefixRange[K1, K2, K3]{
+		k1:    k1,
+		k2:    k2,
+		order: t.order,
+	}
+}
+
+// StartInclusive makes the range contain only keys whose K2 is bigger or equal to the provided start K2.
+func (t TripleRange[K1, K2, K3]) StartInclusive(start K2) TripleRange[K1, K2, K3] {
+	t.start = BoundInclusive(start)
+	return t
+}
+
+// StartExclusive makes the range contain only keys whose K2 is bigger than the provided start K2.
+func (t TripleRange[K1, K2, K3]) StartExclusive(start K2) TripleRange[K1, K2, K3] {
+	t.start = BoundExclusive(start)
+	return t
+}
+
+// EndInclusive makes the range contain only keys whose K2 is smaller or equal to the provided end K2.
+func (t TripleRange[K1, K2, K3]) EndInclusive(end K2) TripleRange[K1, K2, K3] {
+	t.end = BoundInclusive(end)
+	return t
+}
+
+// EndExclusive makes the range contain only keys whose K2 is smaller than the provided end K2.
+func (t TripleRange[K1, K2, K3]) EndExclusive(end K2) TripleRange[K1, K2, K3] {
+	t.end = BoundExclusive(end)
+	return t
+}
+
+// Descending makes the range run in reverse (bigger->smaller, instead of smaller->bigger)
+func (t TripleRange[K1, K2, K3]) Descending() TripleRange[K1, K2, K3] {
+	t.order = OrderDescending
+	return t
+}
+
+// RangeValues implements Ranger for Triple[K1, K2, K3].
+// If start and end are set, prefix must be set too or the function call will panic.
+// The bounds apply to K2, so all the keys sharing the same K2 are included or excluded together.
+// Example:
+// given the following keys in storage:
+// Triple["ubtc:unusd", "alice", 0]
+// Triple["ubtc:unusd", "bob", 0]
+// Triple["ubtc:unusd", "bob", 1]
+// Triple["ueth:unusd", "bob", 0]
+// doing: TripleRange[string, string, uint64]{}.Prefix("ubtc:unusd").StartExclusive("alice")
+// returns: Triple["ubtc:unusd", "bob", 0], Triple["ubtc:unusd", "bob", 1]
+func (t TripleRange[K1, K2, K3]) RangeValues() (prefix *Triple[K1, K2, K3], start *Bound[Triple[K1, K2, K3]], end *Bound[Triple[K1, K2, K3]], order Order) {
+	if (t.end != nil || t.start != nil) && t.prefix == nil {
+		panic("invalid TripleRange usage: if end or start are set, prefix must be set too")
+	}
+	if t.prefix != nil {
+		prefix = &Triple[K1, K2, K3]{k1: t.prefix}
+	}
+	if t.start != nil {
+		start = &Bound[Triple[K1, K2, K3]]{
+			value:     Triple[K1, K2, K3]{k2: &t.start.value},
+			inclusive: t.start.inclusive,
+			partial:   true,
+		}
+	}
+	if t.end != nil {
+		end = &Bound[Triple[K1, K2, K3]]{
+			value:     Triple[K1, K2, K3]{k2: &t.end.value},
+			inclusive: t.end.inclusive,
+			partial:   true,
+		}
+	}
+	order = t.order
+	return
+}
+
+// TripleSuperPrefixRange implements the Ranger interface
+// to range over Triple keys prefixed by K1 and K2, and bounded over K3.
+// It is created using TripleRange.SuperPrefix.
+type TripleSuperPrefixRange[K1, K2, K3 any] struct {
+	k1    K1
+	k2    K2
+	start *Bound[K3]
+	end   *Bound[K3]
+	order Order
+}
+
+// StartInclusive makes the range contain only keys which are bigger or equal to the provided start K3.
+func (t TripleSuperPrefixRange[K1, K2, K3]) StartInclusive(start K3) TripleSuperPrefixRange[K1, K2, K3] {
+	t.start = BoundInclusive(start)
+	return t
+}
+
+// StartExclusive makes the range contain only keys which are bigger to the provided start K3.
+func (t TripleSuperPrefixRange[K1, K2, K3]) StartExclusive(start K3) TripleSuperPrefixRange[K1, K2, K3] {
+	t.start = BoundExclusive(start)
+	return t
+}
+
+// EndInclusive makes the range contain only keys which are smaller or equal to the provided end K3.
+func (t TripleSuperPrefixRange[K1, K2, K3]) EndInclusive(end K3) TripleSuperPrefixRange[K1, K2, K3] {
+	t.end = BoundInclusive(end)
+	return t
+}
+
+// EndExclusive makes the range contain only keys which are smaller to the provided end K3.
+func (t TripleSuperPrefixRange[K1, K2, K3]) EndExclusive(end K3) TripleSuperPrefixRange[K1, K2, K3] {
+	t.end = BoundExclusive(end)
+	return t
+}
+
+// Descending makes the range run in reverse (bigger->smaller, instead of smaller->bigger)
+func (t TripleSuperPrefixRange[K1, K2, K3]) Descending() TripleSuperPrefixRange[K1, K2, K3] {
+	t.order = OrderDescending
+	return t
+}
+
+// RangeValues implements Ranger for Triple[K1, K2, K3].
+// The range prefixes over K1 and K2, and goes from K3 start to K3 end (if any are defined).
+func (t TripleSuperPrefixRange[K1, K2, K3]) RangeValues() (prefix *Triple[K1, K2, K3], start *Bound[Triple[K1, K2, K3]], end *Bound[Triple[K1, K2, K3]], order Order) {
+	p := TripleSuperPrefix[K1, K2, K3](t.k1, t.k2)
+	prefix = &p
+	if t.start != nil {
+		start = &Bound[Triple[K1, K2, K3]]{
+			value:     Triple[K1, K2, K3]{k3: &t.start.value},
+			inclusive: t.start.inclusive,
+		}
+	}
+	if t.end != nil {
+		end = &Bound[Triple[K1, K2, K3]]{
+			value:     Triple[K1, K2, K3]{k3: &t.end.value},
+			inclusive: t.end.inclusive,
+		}
+	}
+	order = t.order
+	return
+}
+
+// stringifyPart returns the string representation of
+// a composite key part, or nil if the part is not set.
+func stringifyPart[K any](kc KeyEncoder[K], k *K) *string {
+	if k == nil {
+		return nil
+	}
+	s := kc.Stringify(*k)
+	return &s
+}
+
+// stringifyComposite returns the string representation of a composite key
+// given the string representation of its parts, in the same format of Pair.
+func stringifyComposite(parts ...*string) string {
+	s := strings.Builder{}
+	s.WriteByte('(')
+	for i, part := range parts {
+		if i != 0 {
+			s.WriteString(", ")
+		}
+		if part == nil {
+			s.WriteString("<nil>")
+			continue
+		}
+		s.WriteByte('"')
+		s.WriteString(*part)
+		s.WriteByte('"')
+	}
+	s.WriteByte(')')
+	return s.String()
+}
@@ -0,0 +1,128 @@
+package collections
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTripleKeyEncoder(t *testing.T) {
+	enc := TripleKeyEncoder(StringKeyEncoder, StringKeyEncoder, Uint64KeyEncoder)
+
+	t.Run("bijectivity", func(t *testing.T) {
+		assertBijective(t, enc, Join3("ubtc:unusd", "trader", uint64(1)))
+	})
+
+	t.Run("encode partial", func(t *testing.T) {
+		require.Equal(t, StringKeyEncoder.Encode("k1"), enc.Encode(TriplePrefix[string, string, uint64]("k1")))
+		require.Equal(t,
+			append(StringKeyEncoder.Encode("k1"), StringKeyEncoder.Encode("k2")...),
+			enc.Encode(TripleSuperPrefix[string, string, uint64]("k1", "k2")),
+		)
+	})
+
+	t.Run("empty panics", func(t *testing.T) {
+		require.Panics(t, func() {
+			enc.Encode(Triple[string, string, uint64]{})
+		})
+	})
+
+	t.Run("stringify", func(t *testing.T) {
+		require.Equal(t, `("k1", "k2", "3")`, enc.Stringify(Join3("k1", "k2", uint64(3))))
+		require.Equal(t, `("k1", <nil>, <nil>)`, enc.Stringify(TriplePrefix[string, string, uint64]("k1")))
+	})
+
+	t.Run("accessors", func(t *testing.T) {
+		k := Join3("k1", "k2", uint64(3))
+		require.Equal(t, "k1", k.K1())
+		require.Equal(t, "k2", k.K2())
+		require.Equal(t, uint64(3), k.K3())
+		require.Equal(t, uint64(0), TriplePrefix[string, string, uint64]("k1").K3())
+	})
+}
+
+func TestTripleRange(t *testing.T) {
+	sk, ctx, _ := deps()
+
+	ks := NewKeySet[Triple[string, string, uint64]](
+		sk,
+		0,
+		TripleKeyEncoder(StringKeyEncoder, StringKeyEncoder, Uint64KeyEncoder),
+	)
+	items := []Triple[string, string, uint64]{
+		Join3("ubtc:unusd", "alice", uint64(0)),
+		Join3("ubtc:unusd", "bob", uint64(0)),
+		Join3("ubtc:unusd", "bob", uint64(1)),
+		Join3("ubtc:unusd", "bob", uint64(2)),
+		Join3("ubtc:unusd", "carl", uint64(0)),
+		Join3("ueth:unusd", "bob", uint64(0)),
+	}
+	for _, i := range items {
+		ks.Insert(ctx, i)
+	}
+
+	// prefix
+	results := ks.Iterate(ctx, TripleRange[string, string, uint64]{}.Prefix("ueth:unusd")).Keys()
+	require.Equal(t, items[5:], results)
+
+	// prefix with bounds on K2, bounds apply to all the keys sharing K2
+	rng := TripleRange[string, string, uint64]{}.Prefix("ubtc:unusd").StartExclusive("alice").EndInclusive("bob")
+	results = ks.Iterate(ctx, rng).Keys()
+	require.Equal(t, items[1:4], results)
+
+	rng = TripleRange[string, string, uint64]{}.Prefix("ubtc:unusd").StartInclusive("bob").EndExclusive("carl")
+	results = ks.Iterate(ctx, rng).Keys()
+	require.Equal(t, items[1:4], results)
+
+	// super prefix
+	results = ks.Iterate(ctx, TripleRange[string, string, uint64]{}.SuperPrefix("ubtc:unusd", "bob")).Keys()
+	require.Equal(t, items[1:4], results)
+
+	// super prefix with bounds on K3
+	superRng := TripleRange[string, string, uint64]{}.
+		SuperPrefix("ubtc:unusd", "bob").
+		StartExclusive(0).
+		EndInclusive(2).
+		Descending()
+	results = ks.Iterate(ctx, superRng).Keys()
+	require.Equal(t, []Triple[string, string, uint64]{items[3], items[2]}, results)
+
+	// panics if prefix is not set but any of start or end is
+	require.Panics(t, func() {
+		TripleRange[string, string, uint64]{}.StartInclusive("bob").RangeValues()
+	})
+	require.Panics(t, func() {
+		TripleRange[string, string, uint64]{}.EndExclusive("bob").RangeValues()
+	})
+}
+
+func TestTripleRangeStartExclusiveMaxPrefix(t *testing.T) {
+	sk, ctx, _ := deps()
+
+	ks := NewKeySet[Triple[uint64, uint64, uint64]](
+		sk,
+		0,
+		TripleKeyEncoder(Uint64KeyEncoder, Uint64KeyEncoder, Uint64KeyEncoder),
+	)
+	ks.Insert(ctx, Join3(uint64(math.MaxUint64), uint64(0), uint64(0)))
+	ks.Insert(ctx, Join3(uint64(math.MaxUint64), uint64(math.MaxUint64), uint64(0)))
+
+	// the encoded bound is made only of 0xFF bytes, so no key follows it.
+	rng := TripleRange[uint64, uint64, uint64]{}.Prefix(math.MaxUint64).StartExclusive(math.MaxUint64)
+	require.Empty(t, ks.Iterate(ctx, rng).Keys())
+	require.Empty(t, ks.Iterate(ctx, rng.Descending()).Keys())
+
+	rev := NewKeySet[Triple[uint64, uint64, uint64]](
+		sk,
+		1,
+		TripleKeyEncoder(Uint64KeyEncoder, ReverseKeyEncoder(Uint64KeyEncoder), Uint64KeyEncoder),
+	)
+	rev.Insert(ctx, Join3(uint64(0), uint64(0), uint64(0)))
+	rev.Insert(ctx, Join3(uint64(0), uint64(1), uint64(0)))
+	require.Empty(t, rev.Iterate(ctx, TripleRange[uint64, uint64, uint64]{}.Prefix(0).StartExclusive(0)).Keys())
+	require.Equal(t,
+		[]Triple[uint64, uint64, uint64]{Join3(uint64(0), uint64(0), uint64(0))},
+		rev.Iterate(ctx, TripleRange[uint64, uint64, uint64]{}.Prefix(0).StartExclusive(1)).Keys(),
+	)
+}
@@ -2,11 +2,10 @@ package collections
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 
 	storetypes "cosmossdk.io/store/types"
-
-	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // KeySet wraps the default Map, but is used only for
@@ -22,25 +21,63 @@ func NewKeySet[K any](sk storetypes.StoreKey, namespace Namespace, keyEncoder Ke
 	return (KeySet[K])(NewMap[K, setObject](sk, namespace, keyEncoder, setObject{}))
 }
 
+// NewKeySetWithStore instantiates a new KeySet which reads from and writes to
+// the KVStore provided by the StoreAccessor.
+func NewKeySetWithStore[K any](sa StoreAccessor, namespace Namespace, keyEncoder KeyEncoder[K]) KeySet[K] {
+	return (KeySet[K])(NewMapWithStore[K, setObject](sa, namespace, keyEncoder, setObject{}))
+}
+
 // Has reports whether the key K is present or not in the set.
-func (s KeySet[K]) Has(ctx sdk.Context, k K) bool {
-	_, err := (Map[K, setObject])(s).Get(ctx, k)
-	return err == nil
+func (s KeySet[K]) Has(ctx context.Context, k K) bool {
+	return (Map[K, setObject])(s).Has(ctx, k)
 }
 
 // Insert inserts the key K in the set.
-func (s KeySet[K]) Insert(ctx sdk.Context, k K) {
+func (s KeySet[K]) Insert(ctx context.Context, k K) {
 	(Map[K, setObject])(s).Insert(ctx, k, setObject{})
 }
 
 // Delete deletes the key from the set.
 // Does not check if the key exists or not.
-func (s KeySet[K]) Delete(ctx sdk.Context, k K) {
+func (s KeySet[K]) Delete(ctx context.Context, k K) {
 	_ = (Map[K, setObject])(s).Delete(ctx, k)
 }
 
+// HasMany reports, for each of the provided keys, whether it is present in the set.
+func (s KeySet[K]) HasMany(ctx context.Context, keys []K) []bool {
+	store := (Map[K, setObject])(s).GetStore(ctx)
+	found := make([]bool, len(keys))
+	for i, k := range keys {
+		found[i] = store.Has(encodeKey(s.kc, k))
+	}
+	return found
+}
+
+// InsertMany inserts the provided keys in the set.
+func (s KeySet[K]) InsertMany(ctx context.Context, keys []K) {
+	store := (Map[K, setObject])(s).GetStore(ctx)
+	for _, k := range keys {
+		store.Set(encodeKey(s.kc, k), []byte{})
+	}
+}
+
+// DeleteMany deletes the provided keys from the set.
+// Does not check if the keys exist or not.
+func (s KeySet[K]) DeleteMany(ctx context.Context, keys []K) {
+	store := (Map[K, setObject])(s).GetStore(ctx)
+	for _, k := range keys {
+		store.Delete(encodeKey(s.kc, k))
+	}
+}
+
+// Clear deletes all the keys within the provided range from the set,
+// a nil Ranger clears the whole set. It returns the number of deleted keys.
+func (s KeySet[K]) Clear(ctx context.Context, r Ranger[K]) int {
+	return (Map[K, setObject])(s).Clear(ctx, r)
+}
+
 // Iterate returns a KeySetIterator over the provided keys.Range of keys.
-func (s KeySet[K]) Iterate(ctx sdk.Context, r Ranger[K]) KeySetIterator[K] {
+func (s KeySet[K]) Iterate(ctx context.Context, r Ranger[K]) KeySetIterator[K] {
 	mi := (Map[K, setObject])(s).Iterate(ctx, r)
 	return (KeySetIterator[K])(mi)
 }
@@ -58,6 +95,13 @@ func (s KeySetIterator[K]) Valid() bool { return (Iterator[K, setObject])(s).Val
 // Key returns the current iterator key.
 func (s KeySetIterator[K]) Key() K { return (Iterator[K, setObject])(s).Key() }
 
+// RawKey returns a copy of the current encoded key, without decoding it.
+func (s KeySetIterator[K]) RawKey() []byte { return (Iterator[K, setObject])(s).RawKey() }
+
+// SafeKey works like Key, but instead of panicking when
+// the key cannot be decoded it returns an error wrapping ErrEncoding.
+func (s KeySetIterator[K]) SafeKey() (K, error) { return (Iterator[K, setObject])(s).SafeKey() }
+
 // Keys consumes the iterator fully and returns all the available keys.
 // The KeySetIterator is closed after this operation.
 func (s KeySetIterator[K]) Keys() []K { return (Iterator[K, setObject])(s).Keys() }
@@ -0,0 +1,109 @@
+package collections
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// The following operations combine two KeySet which use the same KeyEncoder,
+// as sorted merges of their keys, without decoding nor materializing them.
+// The provided Ranger, if not nil, limits the operations to the keys within it.
+// The Into operations buffer the keys to insert, and write them into dst
+// only once the iteration over s and other is done, as stores cannot be
+// written while they are iterated over, so the memory they use grows with
+// the number of keys inserted into dst.
+// dst must not share both its store and its namespace with s or other, or the
+// operations panic. The stores are told apart using the StoreKey of the KeySets
+// created with NewKeySet, and by comparing the KVStore opened by the StoreAccessor
+// of the other ones.
+
+// UnionInto inserts into dst the keys which are in s or other.
+// It returns the number of keys inserted into dst, including the ones already present.
+func (s KeySet[K]) UnionInto(ctx context.Context, other, dst KeySet[K], rng Ranger[K]) int {
+	dst.checkDistinct(ctx, s, other)
+	iter := s.Iterate(ctx, orAll(rng)).Union(other.Iterate(ctx, orAll(rng)))
+	return dst.insertRaw(ctx, iter)
+}
+
+// IntersectInto inserts into dst the keys which are both in s and in other.
+// It returns the number of keys inserted into dst, including the ones already present.
+func (s KeySet[K]) IntersectInto(ctx context.Context, other, dst KeySet[K], rng Ranger[K]) int {
+	dst.checkDistinct(ctx, s, other)
+	iter := s.Iterate(ctx, orAll(rng)).Intersect(other.Iterate(ctx, orAll(rng)))
+	return dst.insertRaw(ctx, iter)
+}
+
+// DifferenceInto inserts into dst the keys which are in s but not in other.
+// It returns the number of keys inserted into dst, including the ones already present.
+func (s KeySet[K]) DifferenceInto(ctx context.Context, other, dst KeySet[K], rng Ranger[K]) int {
+	dst.checkDistinct(ctx, s, other)
+	iter := s.Iterate(ctx, orAll(rng)).Difference(other.Iterate(ctx, orAll(rng)))
+	return dst.insertRaw(ctx, iter)
+}
+
+// IsSubset reports whether all the keys of s are also in other.
+func (s KeySet[K]) IsSubset(ctx context.Context, other KeySet[K], rng Ranger[K]) bool {
+	iter := s.Iterate(ctx, orAll(rng)).Difference(other.Iterate(ctx, orAll(rng)))
+	defer iter.Close()
+	return !iter.Valid()
+}
+
+// Equal reports whether s and other contain the same keys.
+func (s KeySet[K]) Equal(ctx context.Context, other KeySet[K], rng Ranger[K]) bool {
+	a, b := s.Iterate(ctx, orAll(rng)), other.Iterate(ctx, orAll(rng))
+	defer a.Close()
+	defer b.Close()
+
+	for ; a.Valid() && b.Valid(); a.Next() {
+		if !bytes.Equal(a.RawKey(), b.RawKey()) {
+			return false
+		}
+		b.Next()
+	}
+	return a.Valid() == b.Valid()
+}
+
+// insertRaw inserts the encoded keys provided by the KeySetIterator, which is
+// fully consumed and closed before writing, and returns the number of inserted keys.
+func (s KeySet[K]) insertRaw(ctx context.Context, iter KeySetIterator[K]) int {
+	keys := (Iterator[K, setObject])(iter).rawKeys()
+
+	store := (Map[K, setObject])(s).GetStore(ctx)
+	value := setObject{}.Encode(setObject{})
+	for _, key := range keys {
+		store.Set(key, value)
+	}
+	return len(keys)
+}
+
+// checkDistinct panics if s shares its store and its namespace with any of the provided KeySets.
+func (s KeySet[K]) checkDistinct(ctx context.Context, others ...KeySet[K]) {
+	for _, other := range others {
+		if bytes.Equal(s.prefix, other.prefix) && s.sameStore(ctx, other) {
+			panic(fmt.Errorf("collections: the destination KeySet must not be a source KeySet, both have namespace %x", s.prefix))
+		}
+	}
+}
+
+// sameStore reports whether s and other are known to access the same KVStore.
+func (s KeySet[K]) sameStore(ctx context.Context, other KeySet[K]) bool {
+	if s.storeKey != nil && other.storeKey != nil {
+		return s.storeKey == other.storeKey
+	}
+	a, b := s.sa(ctx), other.sa(ctx)
+	// comparing interfaces holding the same non comparable type panics.
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// orAll returns the provided Ranger, or a Range over all the keys if it is nil.
+func orAll[K any](rng Ranger[K]) Ranger[K] {
+	if rng == nil {
+		return Range[K]{}
+	}
+	return rng
+}
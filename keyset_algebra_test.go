@@ -0,0 +1,113 @@
+package collections
+
+import (
+	"context"
+	"testing"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store"
+	"cosmossdk.io/store/cachekv"
+	"cosmossdk.io/store/dbadapter"
+	"cosmossdk.io/store/metrics"
+	storetypes "cosmossdk.io/store/types"
+	dbm "github.com/cosmos/cosmos-db"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySetAlgebra(t *testing.T) {
+	sk, ctx, _ := deps()
+	newSet := func(namespace Namespace, keys ...uint64) KeySet[uint64] {
+		ks := NewKeySet[uint64](sk, namespace, Uint64KeyEncoder)
+		ks.InsertMany(ctx, keys)
+		return ks
+	}
+	keys := func(ks KeySet[uint64]) []uint64 { return ks.Iterate(ctx, Range[uint64]{}).Keys() }
+
+	a := newSet(0, 1, 2, 3, 5, 8)
+	b := newSet(1, 2, 3, 4, 8, 9)
+
+	t.Run("union", func(t *testing.T) {
+		dst := newSet(10, 100)
+		require.Equal(t, 7, a.UnionInto(ctx, b, dst, nil))
+		require.Equal(t, []uint64{1, 2, 3, 4, 5, 8, 9, 100}, keys(dst))
+	})
+
+	t.Run("intersect", func(t *testing.T) {
+		dst := newSet(11)
+		require.Equal(t, 3, a.IntersectInto(ctx, b, dst, nil))
+		require.Equal(t, []uint64{2, 3, 8}, keys(dst))
+	})
+
+	t.Run("difference", func(t *testing.T) {
+		dst := newSet(12)
+		require.Equal(t, 2, a.DifferenceInto(ctx, b, dst, nil))
+		require.Equal(t, []uint64{1, 5}, keys(dst))
+	})
+
+	t.Run("ranged", func(t *testing.T) {
+		dst := newSet(13)
+		rng := Range[uint64]{}.StartInclusive(3).EndExclusive(9)
+		require.Equal(t, 4, a.UnionInto(ctx, b, dst, rng))
+		require.Equal(t, []uint64{3, 4, 5, 8}, keys(dst))
+		require.True(t, newSet(14, 3, 4).IsSubset(ctx, b, nil))
+		require.False(t, a.IsSubset(ctx, b, nil))
+		require.True(t, a.IsSubset(ctx, b, Range[uint64]{}.StartInclusive(2).EndInclusive(3)))
+	})
+
+	t.Run("subset and equal", func(t *testing.T) {
+		empty := newSet(15)
+		require.True(t, empty.IsSubset(ctx, a, nil))
+		require.False(t, a.IsSubset(ctx, empty, nil))
+		require.True(t, a.IsSubset(ctx, a, nil))
+
+		require.True(t, a.Equal(ctx, newSet(16, 1, 2, 3, 5, 8), nil))
+		require.False(t, a.Equal(ctx, newSet(17, 1, 2, 3, 5), nil))
+		require.False(t, a.Equal(ctx, newSet(18, 1, 2, 3, 5, 8, 9), nil))
+		require.False(t, a.Equal(ctx, b, nil))
+		require.True(t, a.Equal(ctx, b, Range[uint64]{}.StartInclusive(2).EndInclusive(3)))
+		require.True(t, empty.Equal(ctx, newSet(19), nil))
+	})
+
+	t.Run("destination must not be a source", func(t *testing.T) {
+		require.Panics(t, func() { a.UnionInto(ctx, b, a, nil) })
+		require.Panics(t, func() { a.IntersectInto(ctx, b, b, nil) })
+		// same namespace, hence same state, even if created separately
+		require.Panics(t, func() { a.DifferenceInto(ctx, b, NewKeySet[uint64](sk, 1, Uint64KeyEncoder), nil) })
+		require.Equal(t, []uint64{1, 2, 3, 5, 8}, keys(a))
+	})
+
+}
+
+func TestKeySetAlgebraStores(t *testing.T) {
+	// every module numbers its namespaces from 0, so KeySets
+	// of different stores often share their namespace.
+	sk1, sk2 := storetypes.NewKVStoreKey("one"), storetypes.NewKVStoreKey("two")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db, log.NewNopLogger(), metrics.NewNoOpMetrics())
+	ms.MountStoreWithDB(sk1, storetypes.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(sk2, storetypes.StoreTypeIAVL, db)
+	require.NoError(t, ms.LoadLatestVersion())
+	ctx := sdk.Context{}.WithMultiStore(ms).WithGasMeter(storetypes.NewInfiniteGasMeter())
+
+	a := NewKeySet[uint64](sk1, 0, Uint64KeyEncoder)
+	a.InsertMany(ctx, []uint64{1, 2})
+	b := NewKeySet[uint64](sk1, 1, Uint64KeyEncoder)
+	b.InsertMany(ctx, []uint64{2, 3})
+
+	dst := NewKeySet[uint64](sk2, 0, Uint64KeyEncoder)
+	require.Equal(t, 3, a.UnionInto(ctx, b, dst, nil))
+	require.Equal(t, []uint64{1, 2, 3}, dst.Iterate(ctx, Range[uint64]{}).Keys())
+
+	svcDst := NewKeySetWithStore[uint64](KVStoreServiceAccessor(kvStoreService{sk2}), 1, Uint64KeyEncoder)
+	require.Equal(t, 1, a.IntersectInto(ctx, b, svcDst, nil))
+	require.Equal(t, []uint64{2}, svcDst.Iterate(ctx, Range[uint64]{}).Keys())
+
+	// KeySets created with a StoreAccessor are compared using the KVStore it opens.
+	kv := cachekv.NewStore(dbadapter.Store{DB: dbm.NewMemDB()})
+	sa := func(context.Context) storetypes.KVStore { return kv }
+	c := NewKeySetWithStore[uint64](sa, 0, Uint64KeyEncoder)
+	c.Insert(ctx, 1)
+	require.Panics(t, func() { c.DifferenceInto(ctx, a, NewKeySetWithStore[uint64](sa, 0, Uint64KeyEncoder), nil) })
+	require.Equal(t, 1, c.DifferenceInto(ctx, b, NewKeySetWithStore[uint64](sa, 1, Uint64KeyEncoder), nil))
+}
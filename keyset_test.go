@@ -21,6 +21,28 @@ func TestKeySet(t *testing.T) {
 	require.False(t, keyset.Has(ctx, key))
 }
 
+func TestKeySetBatch(t *testing.T) {
+	sk, ctx, _ := deps()
+	keyset := NewKeySet[string](sk, 0, StringKeyEncoder)
+
+	keyset.InsertMany(ctx, []string{"a", "b", "c"})
+	require.Equal(t, []bool{true, false, true}, keyset.HasMany(ctx, []string{"a", "d", "c"}))
+
+	keyset.DeleteMany(ctx, []string{"a", "d"})
+	require.Equal(t, []string{"b", "c"}, keyset.Iterate(ctx, Range[string]{}).Keys())
+}
+
+func TestKeySetClear(t *testing.T) {
+	sk, ctx, _ := deps()
+	keyset := NewKeySet[uint64](sk, 0, Uint64KeyEncoder)
+	keyset.InsertMany(ctx, []uint64{1, 2, 3, 4})
+
+	require.Equal(t, 2, keyset.Clear(ctx, Range[uint64]{}.StartExclusive(2)))
+	require.Equal(t, []uint64{1, 2}, keyset.Iterate(ctx, Range[uint64]{}).Keys())
+	require.Equal(t, 2, keyset.Clear(ctx, nil))
+	require.False(t, keyset.Has(ctx, 1))
+}
+
 func TestKeySet_Iterate(t *testing.T) {
 	sk, ctx, _ := deps()
 	keyset := NewKeySet[string](sk, 0, StringKeyEncoder)
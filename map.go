@@ -1,6 +1,7 @@
 package collections
 
 import (
+	"context"
 	"fmt"
 
 	"cosmossdk.io/store"
@@ -18,7 +19,9 @@ type Map[K, V any] struct {
 	vc ValueEncoder[V]
 
 	prefix []byte
-	sk     storetypes.StoreKey
+	sa     StoreAccessor
+	// storeKey is the StoreKey opened by sa, if the Map was created with one.
+	storeKey storetypes.StoreKey
 
 	typeName string
 }
@@ -28,24 +31,34 @@ type Map[K, V any] struct {
 // type name for value type V.
 func NewMap[K, V any](
 	sk storetypes.StoreKey, namespace Namespace, kc KeyEncoder[K], vc ValueEncoder[V],
+) Map[K, V] {
+	m := NewMapWithStore[K, V](StoreKeyAccessor(sk), namespace, kc, vc)
+	m.storeKey = sk
+	return m
+}
+
+// NewMapWithStore creates a new Map instance which reads from and writes to
+// the KVStore provided by the StoreAccessor, ex: KVStoreServiceAccessor.
+func NewMapWithStore[K, V any](
+	sa StoreAccessor, namespace Namespace, kc KeyEncoder[K], vc ValueEncoder[V],
 ) Map[K, V] {
 	return Map[K, V]{
 		kc:     kc,
 		vc:     vc,
 		prefix: namespace.Prefix(),
-		sk:     sk,
+		sa:     sa,
 		//nolint
 		typeName: vc.(ValueEncoder[V]).Name(), // go1.19 compiler bug
 	}
 }
 
-func (m Map[K, V]) Insert(ctx sdk.Context, k K, v V) {
+func (m Map[K, V]) Insert(ctx context.Context, k K, v V) {
 	m.GetStore(ctx).
-		Set(m.kc.Encode(k), m.vc.Encode(v))
+		Set(encodeKey(m.kc, k), m.vc.Encode(v))
 }
 
-func (m Map[K, V]) Get(ctx sdk.Context, k K) (v V, err error) {
-	vBytes := m.GetStore(ctx).Get(m.kc.Encode(k))
+func (m Map[K, V]) Get(ctx context.Context, k K) (v V, err error) {
+	vBytes := m.GetStore(ctx).Get(encodeKey(m.kc, k))
 	if vBytes == nil {
 		return v, fmt.Errorf("%w: '%s' with key %s", ErrNotFound, m.typeName, m.kc.Stringify(k))
 	}
@@ -53,7 +66,22 @@ func (m Map[K, V]) Get(ctx sdk.Context, k K) (v V, err error) {
 	return m.vc.Decode(vBytes), nil
 }
 
-func (m Map[K, V]) GetOr(ctx sdk.Context, key K, def V) (v V) {
+// SafeGet works like Get, but instead of panicking when the key cannot be encoded
+// or the value cannot be decoded, it returns an error wrapping ErrEncoding.
+func (m Map[K, V]) SafeGet(ctx context.Context, k K) (v V, err error) {
+	kBytes, err := KeyCodecFromEncoder(m.kc).Encode(k)
+	if err != nil {
+		return v, err
+	}
+	vBytes := m.GetStore(ctx).Get(kBytes)
+	if vBytes == nil {
+		return v, fmt.Errorf("%w: '%s' with key %s", ErrNotFound, m.typeName, m.kc.Stringify(k))
+	}
+
+	return ValueCodecFromEncoder(m.vc).Decode(vBytes)
+}
+
+func (m Map[K, V]) GetOr(ctx context.Context, key K, def V) (v V) {
 	v, err := m.Get(ctx, key)
 	if err == nil {
 		return
@@ -62,10 +90,51 @@ func (m Map[K, V]) GetOr(ctx sdk.Context, key K, def V) (v V) {
 	return def
 }
 
+// Has reports whether the key is present in the map.
+// The value is not read nor decoded.
+func (m Map[K, V]) Has(ctx context.Context, k K) bool {
+	return m.GetStore(ctx).Has(encodeKey(m.kc, k))
+}
+
+// Update applies the update function to the value associated with the key
+// and stores the result. Returns an error if the key does not exist or if the
+// update function fails, in which case the state is not modified.
+func (m Map[K, V]) Update(ctx context.Context, k K, update func(V) (V, error)) error {
+	kBytes := encodeKey(m.kc, k)
+	store := m.GetStore(ctx)
+	vBytes := store.Get(kBytes)
+	if vBytes == nil {
+		return fmt.Errorf("%w: '%s' with key %s", ErrNotFound, m.typeName, m.kc.Stringify(k))
+	}
+	v, err := update(m.vc.Decode(vBytes))
+	if err != nil {
+		return err
+	}
+	store.Set(kBytes, m.vc.Encode(v))
+
+	return nil
+}
+
+// Upsert applies the upsert function to the value associated with the key
+// and stores the result. If the key does not exist, the upsert function is
+// called with the zero value of V and found set to false.
+func (m Map[K, V]) Upsert(ctx context.Context, k K, upsert func(old V, found bool) V) {
+	kBytes := encodeKey(m.kc, k)
+	store := m.GetStore(ctx)
+	var (
+		old   V
+		found bool
+	)
+	if vBytes := store.Get(kBytes); vBytes != nil {
+		old, found = m.vc.Decode(vBytes), true
+	}
+	store.Set(kBytes, m.vc.Encode(upsert(old, found)))
+}
+
 // Delete removes the key-value pair associated with the key from the map.
 // Returns an error if the key does not exist.
-func (m Map[K, V]) Delete(ctx sdk.Context, k K) error {
-	kBytes := m.kc.Encode(k)
+func (m Map[K, V]) Delete(ctx context.Context, k K) error {
+	kBytes := encodeKey(m.kc, k)
 	store := m.GetStore(ctx)
 	if !store.Has(kBytes) {
 		return fmt.Errorf("%w: '%s' with key %s", ErrNotFound, m.typeName, m.kc.Stringify(k))
@@ -75,18 +144,106 @@ func (m Map[K, V]) Delete(ctx sdk.Context, k K) error {
 	return nil
 }
 
+// GetMany returns the values associated with the provided keys, in the same order.
+// found[i] reports whether keys[i] is present in the map, if it is not
+// values[i] is the zero value of V.
+func (m Map[K, V]) GetMany(ctx context.Context, keys []K) (values []V, found []bool) {
+	store := m.GetStore(ctx)
+	values = make([]V, len(keys))
+	found = make([]bool, len(keys))
+	for i, k := range keys {
+		vBytes := store.Get(encodeKey(m.kc, k))
+		if vBytes == nil {
+			continue
+		}
+		values[i], found[i] = m.vc.Decode(vBytes), true
+	}
+	return values, found
+}
+
+// InsertMany inserts the provided key-value pairs in order,
+// so if a key appears more than once the last value wins.
+func (m Map[K, V]) InsertMany(ctx context.Context, kvs []KeyValue[K, V]) {
+	store := m.GetStore(ctx)
+	for _, kv := range kvs {
+		store.Set(encodeKey(m.kc, kv.Key), m.vc.Encode(kv.Value))
+	}
+}
+
+// DeleteMany removes the provided keys from the map. It returns the
+// per key result, errs[i] wraps ErrNotFound if keys[i] did not exist.
+func (m Map[K, V]) DeleteMany(ctx context.Context, keys []K) (errs []error) {
+	store := m.GetStore(ctx)
+	errs = make([]error, len(keys))
+	for i, k := range keys {
+		kBytes := encodeKey(m.kc, k)
+		if !store.Has(kBytes) {
+			errs[i] = fmt.Errorf("%w: '%s' with key %s", ErrNotFound, m.typeName, m.kc.Stringify(k))
+			continue
+		}
+		store.Delete(kBytes)
+	}
+	return errs
+}
+
+// Clear removes all the key-value pairs within the provided range from the map,
+// a nil Ranger clears the whole map. It returns the number of deleted entries.
+// Keys are collected before being deleted, so no write happens while the
+// store is being iterated.
+func (m Map[K, V]) Clear(ctx context.Context, rng Ranger[K]) int {
+	if rng == nil {
+		rng = Range[K]{}
+	}
+	store := m.GetStore(ctx)
+	iter := iteratorFromRange[K, V](store, rng, m.kc, m.vc)
+	keys := iter.rawKeys()
+	for _, kBytes := range keys {
+		store.Delete(kBytes)
+	}
+	return len(keys)
+}
+
 // Iterate returns an iterator that traverses the elements of the map within the
 // specified range. It utilizes the custom key encoder for navigating the
 // underlying storage.
-func (m Map[K, V]) Iterate(ctx sdk.Context, rng Ranger[K]) Iterator[K, V] {
+func (m Map[K, V]) Iterate(ctx context.Context, rng Ranger[K]) Iterator[K, V] {
 	return iteratorFromRange[K, V](m.GetStore(ctx), rng, m.kc, m.vc)
 }
 
+// IterateKeys returns an iterator over the keys of the map within the specified
+// range, the values are never decoded.
+func (m Map[K, V]) IterateKeys(ctx context.Context, rng Ranger[K]) KeySetIterator[K] {
+	iter := iteratorFromRange[K, setObject](m.GetStore(ctx), rng, m.kc, setObject{})
+	return (KeySetIterator[K])(iter)
+}
+
+// Walk calls the walk function on every key-value pair of the map within the
+// provided range, a nil Ranger walks over the whole map. The walk stops when
+// the function returns stop or an error, which is returned by Walk.
+// The underlying iterator is always closed.
+func (m Map[K, V]) Walk(ctx context.Context, rng Ranger[K], walk func(key K, value V) (stop bool, err error)) error {
+	if rng == nil {
+		rng = Range[K]{}
+	}
+	iter := m.Iterate(ctx, rng)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		stop, err := walk(iter.Key(), iter.Value())
+		if err != nil {
+			return err
+		}
+		if stop {
+			return nil
+		}
+	}
+	return nil
+}
+
 // GetStore returns a namespaced version of the underlying KVStore for the map.
 // It is used to access the store using the prefixed namespace.
-func (m Map[K, V]) GetStore(ctx sdk.Context) store.KVStore {
-	kvStore := ctx.KVStore(m.sk) // persistent store
-	return prefix.NewStore(kvStore, m.prefix)
+func (m Map[K, V]) GetStore(ctx context.Context) store.KVStore {
+	return prefix.NewStore(m.sa(ctx), m.prefix)
 }
 
 // MapTransient: A composed, or embedded, version of the `collections.Map` that
@@ -100,14 +257,21 @@ func (m Map[K, V]) GetStore(ctx sdk.Context) store.KVStore {
 //
 // Transient KV stores have markedly lower costs for all operations (10% of the
 // persistent cost) and a read cost per byte of zero.
+//
+// Only GetStore opens the transient KV store, the methods promoted from the
+// embedded Map read from and write to the persistent KV store of the StoreKey.
+// A Map whose methods all operate on the transient KV store can be created
+// using NewMapWithStore and TransientStoreKeyAccessor, or TransientStoreServiceAccessor
+// for modules using the core store API.
 type MapTransient[K, V any] struct {
 	Map[K, V]
+	sk storetypes.StoreKey
 }
 
 // GetStore returns a namespaced version of the underlying KVStore for the map.
 // It is used to access the store using the prefixed namespace.
-func (m MapTransient[K, V]) GetStore(ctx sdk.Context) store.KVStore {
-	kvStore := ctx.TransientStore(m.sk)
+func (m MapTransient[K, V]) GetStore(ctx context.Context) store.KVStore {
+	kvStore := sdk.UnwrapSDKContext(ctx).TransientStore(m.sk)
 	return prefix.NewStore(kvStore, m.prefix)
 }
 
@@ -115,13 +279,7 @@ func NewMapTransient[K, V any](
 	sk storetypes.StoreKey, namespace Namespace, kc KeyEncoder[K], vc ValueEncoder[V],
 ) MapTransient[K, V] {
 	return MapTransient[K, V]{
-		Map: Map[K, V]{
-			kc:     kc,
-			vc:     vc,
-			prefix: namespace.Prefix(),
-			sk:     sk,
-			//nolint
-			typeName: vc.(ValueEncoder[V]).Name(), // go1.19 compiler bug
-		},
+		Map: NewMap[K, V](sk, namespace, kc, vc),
+		sk:  sk,
 	}
 }
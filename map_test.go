@@ -1,6 +1,8 @@
 package collections
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"cosmossdk.io/store"
@@ -21,12 +23,13 @@ func TestUpstreamIterAssertions(t *testing.T) {
 }
 
 type MapImpl[K, V any] interface {
-	Delete(ctx sdk.Context, k K) error
-	Get(ctx sdk.Context, k K) (v V, err error)
-	GetOr(ctx sdk.Context, key K, def V) (v V)
-	GetStore(ctx sdk.Context) store.KVStore
-	Insert(ctx sdk.Context, k K, v V)
-	Iterate(ctx sdk.Context, rng Ranger[K]) Iterator[K, V]
+	Delete(ctx context.Context, k K) error
+	Get(ctx context.Context, k K) (v V, err error)
+	GetOr(ctx context.Context, key K, def V) (v V)
+	GetStore(ctx context.Context) store.KVStore
+	Has(ctx context.Context, k K) bool
+	Insert(ctx context.Context, k K, v V)
+	Iterate(ctx context.Context, rng Ranger[K]) Iterator[K, V]
 }
 
 func TestMap(t *testing.T) {
@@ -48,6 +51,128 @@ func TestMapIterate(t *testing.T) {
 	RunTestMapIterate(t, ctx, NewMapTransient[string, string](sk, 1, StringKeyEncoder, stringValue{}))
 }
 
+func TestMapUpdateUpsert(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewMap[string, uint64](sk, 0, StringKeyEncoder, uint64Value{})
+
+	t.Run("update missing key", func(t *testing.T) {
+		err := m.Update(ctx, "missing", func(v uint64) (uint64, error) { return v + 1, nil })
+		require.ErrorIs(t, err, ErrNotFound)
+		require.False(t, m.Has(ctx, "missing"))
+	})
+
+	t.Run("upsert", func(t *testing.T) {
+		add := func(old uint64, found bool) uint64 {
+			if !found {
+				require.Zero(t, old)
+			}
+			return old + 10
+		}
+		m.Upsert(ctx, "balance", add)
+		require.Equal(t, uint64(10), m.GetOr(ctx, "balance", 0))
+		m.Upsert(ctx, "balance", add)
+		require.Equal(t, uint64(20), m.GetOr(ctx, "balance", 0))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		err := m.Update(ctx, "balance", func(v uint64) (uint64, error) { return v - 5, nil })
+		require.NoError(t, err)
+		require.Equal(t, uint64(15), m.GetOr(ctx, "balance", 0))
+	})
+
+	t.Run("update error leaves state untouched", func(t *testing.T) {
+		insufficient := errors.New("insufficient funds")
+		err := m.Update(ctx, "balance", func(v uint64) (uint64, error) {
+			if v < 100 {
+				return v, insufficient
+			}
+			return v - 100, nil
+		})
+		require.ErrorIs(t, err, insufficient)
+		require.Equal(t, uint64(15), m.GetOr(ctx, "balance", 0))
+	})
+}
+
+func TestMapBatch(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewMap[string, uint64](sk, 0, StringKeyEncoder, uint64Value{})
+
+	m.InsertMany(ctx, []KeyValue[string, uint64]{
+		{Key: "a", Value: 1},
+		{Key: "b", Value: 2},
+		{Key: "a", Value: 3},
+	})
+
+	values, found := m.GetMany(ctx, []string{"a", "missing", "b"})
+	require.Equal(t, []uint64{3, 0, 2}, values)
+	require.Equal(t, []bool{true, false, true}, found)
+
+	errs := m.DeleteMany(ctx, []string{"a", "missing", "a"})
+	require.Len(t, errs, 3)
+	require.NoError(t, errs[0])
+	require.ErrorIs(t, errs[1], ErrNotFound)
+	require.ErrorContains(t, errs[1], "missing")
+	require.ErrorIs(t, errs[2], ErrNotFound)
+	require.False(t, m.Has(ctx, "a"))
+	require.True(t, m.Has(ctx, "b"))
+}
+
+func TestMapClear(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewMap[Pair[string, uint64], uint64](sk, 0, PairKeyEncoder(StringKeyEncoder, Uint64KeyEncoder), uint64Value{})
+	// neighbouring namespace which must not be touched
+	other := NewMap[string, uint64](sk, 1, StringKeyEncoder, uint64Value{})
+	other.Insert(ctx, "a", 1)
+
+	for _, k1 := range []string{"a", "b"} {
+		for k2 := uint64(0); k2 < 5; k2++ {
+			m.Insert(ctx, Join(k1, k2), k2)
+		}
+	}
+
+	deleted := m.Clear(ctx, PairRange[string, uint64]{}.Prefix("a").StartInclusive(1).EndExclusive(4))
+	require.Equal(t, 3, deleted)
+	require.Equal(t, []Pair[string, uint64]{Join("a", uint64(0)), Join("a", uint64(4))},
+		m.Iterate(ctx, PairRange[string, uint64]{}.Prefix("a")).Keys())
+
+	require.Equal(t, 5, m.Clear(ctx, PairRange[string, uint64]{}.Prefix("b")))
+	require.Equal(t, 2, m.Clear(ctx, nil))
+	require.Empty(t, m.Iterate(ctx, Range[Pair[string, uint64]]{}).Keys())
+	require.Equal(t, 0, m.Clear(ctx, nil))
+
+	require.True(t, other.Has(ctx, "a"))
+}
+
+func TestMapWalk(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewMap[uint64, uint64](sk, 0, Uint64KeyEncoder, uint64Value{})
+	for i := uint64(0); i < 5; i++ {
+		m.Insert(ctx, i, i*10)
+	}
+
+	var sum uint64
+	err := m.Walk(ctx, nil, func(_, v uint64) (bool, error) {
+		sum += v
+		return false, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), sum)
+
+	var keys []uint64
+	err = m.Walk(ctx, Range[uint64]{}.Descending(), func(k, _ uint64) (bool, error) {
+		keys = append(keys, k)
+		return k == 3, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{4, 3}, keys)
+
+	walkErr := errors.New("walk error")
+	err = m.Walk(ctx, Range[uint64]{}.StartInclusive(2), func(k, _ uint64) (bool, error) {
+		return false, walkErr
+	})
+	require.ErrorIs(t, err, walkErr)
+}
+
 func RunTestMap(t *testing.T, ctx sdk.Context, m MapImpl[string, string]) {
 	key := "id"
 	expected := "test"
@@ -58,9 +183,12 @@ func RunTestMap(t *testing.T, ctx sdk.Context, m MapImpl[string, string]) {
 	require.NoError(t, err)
 	require.Equal(t, expected, got)
 
+	require.True(t, m.Has(ctx, key))
+
 	// test delete and get error
 	err = m.Delete(ctx, key)
 	require.NoError(t, err)
+	require.False(t, m.Has(ctx, key))
 	_, err = m.Get(ctx, key)
 	require.ErrorIs(t, err, ErrNotFound)
 	require.ErrorContains(t, err, "test string") // assert value name is correctly reported
@@ -0,0 +1,64 @@
+package collections
+
+import (
+	"context"
+	"fmt"
+)
+
+// MigrateKeyEncoding rewrites in place the keys of the provided Map, which
+// were encoded using the old KeyEncoder, using the KeyEncoder of the Map.
+// It returns the number of migrated keys.
+// The values are left untouched. The function is meant to be called
+// inside store migrations, for example to move from SdkDecKeyEncoder
+// to DecKeyEncoder:
+//
+//	m := collections.NewMap(sk, 1, collections.DecKeyEncoder, collections.DecValueEncoder)
+//	collections.MigrateKeyEncoding(ctx, m, collections.SdkDecKeyEncoder)
+//
+// Or to move bech32 keyed state to raw address bytes, composite keys included:
+//
+//	m := collections.NewMap(sk, 2,
+//		collections.PairKeyEncoder(collections.AccAddressBytesKeyEncoder, collections.StringKeyEncoder),
+//		collections.IntValueEncoder,
+//	)
+//	collections.MigrateKeyEncoding(ctx, m,
+//		collections.PairKeyEncoder(collections.AccAddressKeyEncoder, collections.StringKeyEncoder),
+//	)
+//
+// The old KeyEncoder must be able to decode the existing keys, so bech32
+// keyed state must be migrated before the bech32 prefix configuration changes.
+// KeySet and IndexedMap keys can be migrated in the same way by converting
+// the KeySet into a Map or by migrating the IndexedMap primary Map and its
+// MultiIndex namespaces.
+// The old keys are loaded into memory before being rewritten, since writing
+// into the store while iterating over it is unsafe.
+func MigrateKeyEncoding[K, V any](ctx context.Context, m Map[K, V], old KeyEncoder[K]) int {
+	store := m.GetStore(ctx)
+
+	var oldKeys, values [][]byte
+	iter := store.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		oldKeys = append(oldKeys, iter.Key())
+		values = append(values, iter.Value())
+	}
+	_ = iter.Close()
+
+	newKeys := make([][]byte, len(oldKeys))
+	for i, oldKey := range oldKeys {
+		read, k := old.Decode(oldKey)
+		if read != len(oldKey) {
+			panic(fmt.Sprintf("key decoder didn't fully consume the key: %T %x %d", old, oldKey, read))
+		}
+		newKeys[i] = m.kc.Encode(k)
+	}
+
+	// we delete all the old keys before writing the new ones,
+	// as the new encoding might collide with old keys.
+	for _, oldKey := range oldKeys {
+		store.Delete(oldKey)
+	}
+	for i, newKey := range newKeys {
+		store.Set(newKey, values[i])
+	}
+	return len(oldKeys)
+}
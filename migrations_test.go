@@ -0,0 +1,62 @@
+package collections
+
+import (
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateKeyEncoding(t *testing.T) {
+	sk, ctx, _ := deps()
+
+	decs := []math.LegacyDec{
+		math.LegacyNewDec(10),
+		math.LegacyMustNewDecFromStr("-0.5"),
+		math.LegacyZeroDec(),
+		math.LegacyNewDec(-3),
+	}
+	old := NewMap[math.LegacyDec, string](sk, 0, SdkDecKeyEncoder, stringValue{})
+	for _, d := range decs {
+		old.Insert(ctx, d, d.String())
+	}
+
+	m := NewMap[math.LegacyDec, string](sk, 0, DecKeyEncoder, stringValue{})
+	migrated := MigrateKeyEncoding(ctx, m, SdkDecKeyEncoder)
+	require.Equal(t, len(decs), migrated)
+
+	kvs := m.Iterate(ctx, Range[math.LegacyDec]{}).KeyValues()
+	require.Len(t, kvs, len(decs))
+	expected := []string{"-3.000000000000000000", "-0.500000000000000000", "0.000000000000000000", "10.000000000000000000"}
+	for i, kv := range kvs {
+		require.Equal(t, expected[i], kv.Key.String())
+		require.Equal(t, expected[i], kv.Value)
+	}
+}
+
+func TestMigrateKeyEncodingBech32(t *testing.T) {
+	sk, ctx, _ := deps()
+
+	addrs := []sdk.AccAddress{
+		secp256k1.GenPrivKey().PubKey().Address().Bytes(),
+		secp256k1.GenPrivKey().PubKey().Address().Bytes(),
+	}
+	oldKc := PairKeyEncoder(AccAddressKeyEncoder, StringKeyEncoder)
+	old := NewMap[Pair[sdk.AccAddress, string], string](sk, 0, oldKc, stringValue{})
+	for _, addr := range addrs {
+		old.Insert(ctx, Join(addr, "unibi"), addr.String())
+	}
+
+	m := NewMap[Pair[sdk.AccAddress, string], string](sk, 0,
+		PairKeyEncoder(AccAddressBytesKeyEncoder, StringKeyEncoder), stringValue{})
+	require.Equal(t, len(addrs), MigrateKeyEncoding(ctx, m, oldKc))
+
+	for _, addr := range addrs {
+		v, err := m.Get(ctx, Join(addr, "unibi"))
+		require.NoError(t, err)
+		require.Equal(t, addr.String(), v)
+	}
+	require.Len(t, m.Iterate(ctx, Range[Pair[sdk.AccAddress, string]]{}).Keys(), len(addrs))
+}
@@ -0,0 +1,133 @@
+package collections
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/store"
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+// Paginate returns a page of the key-value pairs of the map within the provided range,
+// a nil Ranger paginates over the whole map. See paginate for the PageRequest semantics.
+func (m Map[K, V]) Paginate(
+	ctx context.Context, req *query.PageRequest, rng Ranger[K],
+) ([]KeyValue[K, V], *query.PageResponse, error) {
+	return paginate[K, V](m.GetStore(ctx), req, rng, m.kc, m.vc)
+}
+
+// Paginate returns a page of the keys of the set within the provided range,
+// a nil Ranger paginates over the whole set. See Map.Paginate.
+func (s KeySet[K]) Paginate(
+	ctx context.Context, req *query.PageRequest, rng Ranger[K],
+) ([]K, *query.PageResponse, error) {
+	kvs, resp, err := (Map[K, setObject])(s).Paginate(ctx, req, rng)
+	if err != nil {
+		return nil, nil, err
+	}
+	keys := make([]K, len(kvs))
+	for i, kv := range kvs {
+		keys[i] = kv.Key
+	}
+	return keys, resp, nil
+}
+
+// Paginate returns a page of the objects whose primary keys are within the provided
+// range, a nil Ranger paginates over the whole map. See Map.Paginate.
+func (i IndexedMap[PK, V, I]) Paginate(
+	ctx context.Context, req *query.PageRequest, rng Ranger[PK],
+) ([]KeyValue[PK, V], *query.PageResponse, error) {
+	return i.m.Paginate(ctx, req, rng)
+}
+
+// Paginate returns a page of the joint indexing and primary keys within the provided
+// range, a nil Ranger paginates over the whole index. See Map.Paginate.
+func (i MultiIndex[IK, PK, V]) Paginate(
+	ctx context.Context, req *query.PageRequest, rng Ranger[Pair[IK, PK]],
+) ([]Pair[IK, PK], *query.PageResponse, error) {
+	return i.jointKeys.Paginate(ctx, req, rng)
+}
+
+// paginate iterates over the provided range of the namespaced store s following
+// the semantics of query.Paginate:
+//   - Key and Offset are mutually exclusive.
+//   - Key is the NextKey of a previous PageResponse, it is the encoded key
+//     of the first object of the page, relative to the collection namespace.
+//   - if Limit is zero, query.DefaultLimit is used and CountTotal is implied.
+//   - CountTotal is only honoured with offset based pagination.
+//   - Reverse provides the objects in descending order, overriding the Ranger order.
+func paginate[K, V any](
+	s store.KVStore, req *query.PageRequest, rng Ranger[K], kc KeyEncoder[K], vc ValueEncoder[V],
+) ([]KeyValue[K, V], *query.PageResponse, error) {
+	if req == nil {
+		req = &query.PageRequest{}
+	}
+	if req.Key != nil && req.Offset > 0 {
+		return nil, nil, errors.New("collections: invalid pagination request, either offset or key is expected, got both")
+	}
+	limit, countTotal := req.Limit, req.CountTotal
+	if limit == 0 {
+		limit, countTotal = query.DefaultLimit, true
+	}
+	countTotal = countTotal && req.Key == nil
+
+	if rng == nil {
+		rng = Range[K]{}
+	}
+	rr := rawRangeFromRanger(s, rng, kc)
+	if req.Reverse {
+		rr.order = OrderDescending
+	}
+	if req.Key != nil {
+		if !bytes.HasPrefix(req.Key, rr.prefixBytes) {
+			return nil, nil, fmt.Errorf("collections: invalid pagination key %x, it is not within the range prefix %x", req.Key, rr.prefixBytes)
+		}
+		key := bytes.Clone(req.Key[len(rr.prefixBytes):])
+		switch rr.order {
+		case OrderAscending:
+			if rr.start == nil || bytes.Compare(key, rr.start) > 0 {
+				rr.start = key
+			}
+		case OrderDescending:
+			// the end of the range is exclusive.
+			key = extendOneByte(key)
+			if rr.end == nil || bytes.Compare(key, rr.end) < 0 {
+				rr.end = key
+			}
+		}
+		if rr.start != nil && rr.end != nil && bytes.Compare(rr.start, rr.end) >= 0 {
+			return nil, &query.PageResponse{}, nil
+		}
+	}
+
+	iter := iteratorFromRawRange[K, V](rr, kc, vc)
+	defer iter.Close()
+
+	var (
+		kvs     []KeyValue[K, V]
+		nextKey []byte
+		count   uint64
+	)
+	for ; iter.Valid(); iter.Next() {
+		count++
+		switch {
+		case count <= req.Offset:
+			continue
+		case uint64(len(kvs)) < limit:
+			kvs = append(kvs, iter.KeyValue())
+		case nextKey == nil:
+			nextKey = iter.RawKey()
+		}
+		if nextKey != nil && !countTotal {
+			break
+		}
+	}
+
+	resp := &query.PageResponse{NextKey: nextKey}
+	if countTotal {
+		resp.Total = count
+	}
+	return kvs, resp, nil
+}
@@ -0,0 +1,153 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginate(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewMap[uint64, uint64](sk, 0, Uint64KeyEncoder, uint64Value{})
+	for i := uint64(0); i < 10; i++ {
+		m.Insert(ctx, i, i*10)
+	}
+	keys := func(kvs []KeyValue[uint64, uint64]) []uint64 {
+		ks := make([]uint64, len(kvs))
+		for i, kv := range kvs {
+			ks[i] = kv.Key
+		}
+		return ks
+	}
+
+	t.Run("nil request", func(t *testing.T) {
+		kvs, resp, err := m.Paginate(ctx, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, kvs, 10)
+		require.Equal(t, KeyValue[uint64, uint64]{Key: 3, Value: 30}, kvs[3])
+		require.Nil(t, resp.NextKey)
+		require.Equal(t, uint64(10), resp.Total)
+	})
+
+	t.Run("key pagination", func(t *testing.T) {
+		kvs, resp, err := m.Paginate(ctx, &query.PageRequest{Limit: 4}, nil)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{0, 1, 2, 3}, keys(kvs))
+		require.Equal(t, Uint64KeyEncoder.Encode(4), resp.NextKey)
+		require.Zero(t, resp.Total)
+
+		kvs, resp, err = m.Paginate(ctx, &query.PageRequest{Key: resp.NextKey, Limit: 4, CountTotal: true}, nil)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{4, 5, 6, 7}, keys(kvs))
+		// count total is ignored with key pagination
+		require.Zero(t, resp.Total)
+
+		kvs, resp, err = m.Paginate(ctx, &query.PageRequest{Key: resp.NextKey, Limit: 4}, nil)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{8, 9}, keys(kvs))
+		require.Nil(t, resp.NextKey)
+	})
+
+	t.Run("offset pagination", func(t *testing.T) {
+		kvs, resp, err := m.Paginate(ctx, &query.PageRequest{Offset: 3, Limit: 2, CountTotal: true}, nil)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{3, 4}, keys(kvs))
+		require.Equal(t, Uint64KeyEncoder.Encode(5), resp.NextKey)
+		require.Equal(t, uint64(10), resp.Total)
+
+		kvs, resp, err = m.Paginate(ctx, &query.PageRequest{Offset: 20, Limit: 2}, nil)
+		require.NoError(t, err)
+		require.Empty(t, kvs)
+		require.Nil(t, resp.NextKey)
+	})
+
+	t.Run("reverse", func(t *testing.T) {
+		kvs, resp, err := m.Paginate(ctx, &query.PageRequest{Limit: 3, Reverse: true}, nil)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{9, 8, 7}, keys(kvs))
+		require.Equal(t, Uint64KeyEncoder.Encode(6), resp.NextKey)
+
+		kvs, _, err = m.Paginate(ctx, &query.PageRequest{Key: resp.NextKey, Limit: 3, Reverse: true}, nil)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{6, 5, 4}, keys(kvs))
+	})
+
+	t.Run("ranger", func(t *testing.T) {
+		rng := Range[uint64]{}.StartInclusive(2).EndExclusive(6)
+		kvs, resp, err := m.Paginate(ctx, &query.PageRequest{Limit: 3}, rng)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{2, 3, 4}, keys(kvs))
+
+		kvs, resp, err = m.Paginate(ctx, &query.PageRequest{Key: resp.NextKey, Limit: 3}, rng)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{5}, keys(kvs))
+		require.Nil(t, resp.NextKey)
+
+		// keys outside of the range produce empty pages
+		kvs, _, err = m.Paginate(ctx, &query.PageRequest{Key: Uint64KeyEncoder.Encode(8)}, rng)
+		require.NoError(t, err)
+		require.Empty(t, kvs)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, _, err := m.Paginate(ctx, &query.PageRequest{Key: Uint64KeyEncoder.Encode(1), Offset: 1}, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestPaginatePrefix(t *testing.T) {
+	sk, ctx, _ := deps()
+	ks := NewKeySet[Pair[string, uint64]](sk, 0, PairKeyEncoder(StringKeyEncoder, Uint64KeyEncoder))
+	for i := uint64(0); i < 5; i++ {
+		ks.Insert(ctx, Join("a", i))
+		ks.Insert(ctx, Join("b", i))
+	}
+
+	rng := PairRange[string, uint64]{}.Prefix("b")
+	keys, resp, err := ks.Paginate(ctx, &query.PageRequest{Limit: 2}, rng)
+	require.NoError(t, err)
+	require.Equal(t, []Pair[string, uint64]{Join("b", uint64(0)), Join("b", uint64(1))}, keys)
+	// next key is relative to the namespace, so it includes the range prefix
+	require.Equal(t, PairKeyEncoder(StringKeyEncoder, Uint64KeyEncoder).Encode(Join("b", uint64(2))), resp.NextKey)
+
+	keys, resp, err = ks.Paginate(ctx, &query.PageRequest{Key: resp.NextKey, Limit: 5}, rng)
+	require.NoError(t, err)
+	require.Equal(t, []Pair[string, uint64]{Join("b", uint64(2)), Join("b", uint64(3)), Join("b", uint64(4))}, keys)
+	require.Nil(t, resp.NextKey)
+
+	// keys of another prefix are rejected
+	_, _, err = ks.Paginate(ctx, &query.PageRequest{Key: PairKeyEncoder(StringKeyEncoder, Uint64KeyEncoder).Encode(Join("a", uint64(2)))}, rng)
+	require.Error(t, err)
+}
+
+func TestPaginateIndexes(t *testing.T) {
+	sk, ctx, _ := deps()
+	m := NewIndexedMap[uint64, person, indexes](
+		sk, 0,
+		Uint64KeyEncoder, jsonValue[person]{},
+		indexes{
+			City: NewMultiIndex[string, uint64, person](sk, 1,
+				StringKeyEncoder, Uint64KeyEncoder,
+				func(v person) string {
+					return v.City
+				}),
+		},
+	)
+	m.Insert(ctx, 0, person{ID: 0, City: "milan"})
+	m.Insert(ctx, 1, person{ID: 1, City: "new york"})
+	m.Insert(ctx, 2, person{ID: 2, City: "milan"})
+
+	kvs, resp, err := m.Paginate(ctx, &query.PageRequest{Limit: 1, CountTotal: true}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []KeyValue[uint64, person]{{Key: 0, Value: person{0, "milan"}}}, kvs)
+	require.Equal(t, uint64(3), resp.Total)
+
+	keys, resp, err := m.Indexes.City.Paginate(ctx, &query.PageRequest{Limit: 1}, PairRange[string, uint64]{}.Prefix("milan"))
+	require.NoError(t, err)
+	require.Equal(t, []Pair[string, uint64]{Join("milan", uint64(0))}, keys)
+	keys, resp, err = m.Indexes.City.Paginate(ctx, &query.PageRequest{Key: resp.NextKey, Limit: 1}, PairRange[string, uint64]{}.Prefix("milan"))
+	require.NoError(t, err)
+	require.Equal(t, []Pair[string, uint64]{Join("milan", uint64(2))}, keys)
+	require.Nil(t, resp.NextKey)
+}
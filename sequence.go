@@ -1,6 +1,8 @@
 package collections
 
 import (
+	"context"
+	"fmt"
 	"strconv"
 
 	storetypes "cosmossdk.io/store/types"
@@ -24,9 +26,17 @@ func NewSequence(sk storetypes.StoreKey, namespace Namespace) Sequence {
 	}
 }
 
+// NewSequenceWithStore instantiates a new sequence object which reads from and
+// writes to the KVStore provided by the StoreAccessor.
+func NewSequenceWithStore(sa StoreAccessor, namespace Namespace) Sequence {
+	return Sequence{
+		sequence: NewItemWithStore[uint64](sa, namespace, uint64Value{}),
+	}
+}
+
 // Next returns the next available sequence number
 // and also increases the sequence number count.
-func (s Sequence) Next(ctx sdk.Context) uint64 {
+func (s Sequence) Next(ctx context.Context) uint64 {
 	// get current
 	seq := s.Peek(ctx)
 	// increase
@@ -36,12 +46,12 @@ func (s Sequence) Next(ctx sdk.Context) uint64 {
 }
 
 // Peek gets the next available sequence number without increasing it.
-func (s Sequence) Peek(ctx sdk.Context) uint64 {
+func (s Sequence) Peek(ctx context.Context) uint64 {
 	return s.sequence.GetOr(ctx, DefaultSequenceStart)
 }
 
 // Set hard resets the sequence to the provided number.
-func (s Sequence) Set(ctx sdk.Context, u uint64) {
+func (s Sequence) Set(ctx context.Context, u uint64) {
 	s.sequence.Set(ctx, u)
 }
 
@@ -52,3 +62,45 @@ func (u uint64Value) Encode(value uint64) []byte    { return sdk.Uint64ToBigEndi
 func (u uint64Value) Decode(b []byte) uint64        { return sdk.BigEndianToUint64(b) }
 func (u uint64Value) Stringify(value uint64) string { return strconv.FormatUint(value, 10) }
 func (u uint64Value) Name() string                  { return "uint64" }
+
+// uint32Value implements a ValueEncoder for uint32
+type uint32Value struct{}
+
+func (u uint32Value) Encode(value uint32) []byte    { return Uint32KeyEncoder.Encode(value) }
+func (u uint32Value) Stringify(value uint32) string { return Uint32KeyEncoder.Stringify(value) }
+func (u uint32Value) Name() string                  { return "uint32" }
+func (u uint32Value) Decode(b []byte) uint32 {
+	if len(b) != 4 {
+		panic(fmt.Errorf("invalid uint32 value bytes: %s", HumanizeBytes(b)))
+	}
+	_, v := Uint32KeyEncoder.Decode(b)
+	return v
+}
+
+// uint16Value implements a ValueEncoder for uint16
+type uint16Value struct{}
+
+func (u uint16Value) Encode(value uint16) []byte    { return Uint16KeyEncoder.Encode(value) }
+func (u uint16Value) Stringify(value uint16) string { return Uint16KeyEncoder.Stringify(value) }
+func (u uint16Value) Name() string                  { return "uint16" }
+func (u uint16Value) Decode(b []byte) uint16 {
+	if len(b) != 2 {
+		panic(fmt.Errorf("invalid uint16 value bytes: %s", HumanizeBytes(b)))
+	}
+	_, v := Uint16KeyEncoder.Decode(b)
+	return v
+}
+
+// uint8Value implements a ValueEncoder for uint8
+type uint8Value struct{}
+
+func (u uint8Value) Encode(value uint8) []byte    { return Uint8KeyEncoder.Encode(value) }
+func (u uint8Value) Stringify(value uint8) string { return Uint8KeyEncoder.Stringify(value) }
+func (u uint8Value) Name() string                 { return "uint8" }
+func (u uint8Value) Decode(b []byte) uint8 {
+	if len(b) != 1 {
+		panic(fmt.Errorf("invalid uint8 value bytes: %s", HumanizeBytes(b)))
+	}
+	_, v := Uint8KeyEncoder.Decode(b)
+	return v
+}
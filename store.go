@@ -0,0 +1,126 @@
+package collections
+
+import (
+	"context"
+	"io"
+
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/store"
+	"cosmossdk.io/store/cachekv"
+	"cosmossdk.io/store/tracekv"
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// StoreAccessor returns the KVStore a collection reads from and writes to, given the context.
+// Collections can be created on top of a StoreAccessor using the WithStore constructors,
+// ex: NewMapWithStore, NewKeySetWithStore, NewItemWithStore.
+type StoreAccessor func(ctx context.Context) store.KVStore
+
+// StoreKeyAccessor returns a StoreAccessor which opens the persistent KVStore
+// of the provided storetypes.StoreKey. The context must be, or wrap, an sdk.Context.
+func StoreKeyAccessor(sk storetypes.StoreKey) StoreAccessor {
+	return func(ctx context.Context) store.KVStore {
+		return sdk.UnwrapSDKContext(ctx).KVStore(sk)
+	}
+}
+
+// TransientStoreKeyAccessor returns a StoreAccessor which opens the transient KVStore
+// of the provided storetypes.StoreKey. The context must be, or wrap, an sdk.Context.
+func TransientStoreKeyAccessor(sk storetypes.StoreKey) StoreAccessor {
+	return func(ctx context.Context) store.KVStore {
+		return sdk.UnwrapSDKContext(ctx).TransientStore(sk)
+	}
+}
+
+// KVStoreServiceAccessor returns a StoreAccessor which opens
+// the KVStore provided by the core KVStoreService.
+func KVStoreServiceAccessor(ss corestore.KVStoreService) StoreAccessor {
+	return func(ctx context.Context) store.KVStore {
+		return coreStoreAdapter(ss.OpenKVStore(ctx), storetypes.StoreTypeDB)
+	}
+}
+
+// TransientStoreServiceAccessor returns a StoreAccessor which opens
+// the KVStore provided by the core TransientStoreService.
+func TransientStoreServiceAccessor(ss corestore.TransientStoreService) StoreAccessor {
+	return func(ctx context.Context) store.KVStore {
+		return coreStoreAdapter(ss.OpenTransientStore(ctx), storetypes.StoreTypeTransient)
+	}
+}
+
+// MemoryStoreServiceAccessor returns a StoreAccessor which opens
+// the KVStore provided by the core MemoryStoreService.
+func MemoryStoreServiceAccessor(ss corestore.MemoryStoreService) StoreAccessor {
+	return func(ctx context.Context) store.KVStore {
+		return coreStoreAdapter(ss.OpenMemoryStore(ctx), storetypes.StoreTypeMemory)
+	}
+}
+
+// coreKVStore adapts a core KVStore into a store.KVStore, the errors returned
+// by the core KVStore are raised as panics, like the sdk stores do.
+type coreKVStore struct {
+	store     corestore.KVStore
+	storeType storetypes.StoreType
+}
+
+func coreStoreAdapter(s corestore.KVStore, storeType storetypes.StoreType) store.KVStore {
+	return coreKVStore{store: s, storeType: storeType}
+}
+
+func (s coreKVStore) Get(key []byte) []byte {
+	bz, err := s.store.Get(key)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func (s coreKVStore) Has(key []byte) bool {
+	has, err := s.store.Has(key)
+	if err != nil {
+		panic(err)
+	}
+	return has
+}
+
+func (s coreKVStore) Set(key, value []byte) {
+	if err := s.store.Set(key, value); err != nil {
+		panic(err)
+	}
+}
+
+func (s coreKVStore) Delete(key []byte) {
+	if err := s.store.Delete(key); err != nil {
+		panic(err)
+	}
+}
+
+func (s coreKVStore) Iterator(start, end []byte) storetypes.Iterator {
+	iter, err := s.store.Iterator(start, end)
+	if err != nil {
+		panic(err)
+	}
+	return iter
+}
+
+func (s coreKVStore) ReverseIterator(start, end []byte) storetypes.Iterator {
+	iter, err := s.store.ReverseIterator(start, end)
+	if err != nil {
+		panic(err)
+	}
+	return iter
+}
+
+func (s coreKVStore) GetStoreType() storetypes.StoreType {
+	return s.storeType
+}
+
+// CacheWrap branches the core KVStore, writes are flushed to it on Write.
+func (s coreKVStore) CacheWrap() storetypes.CacheWrap {
+	return cachekv.NewStore(s)
+}
+
+func (s coreKVStore) CacheWrapWithTrace(w io.Writer, tc storetypes.TraceContext) storetypes.CacheWrap {
+	return cachekv.NewStore(tracekv.NewStore(s, w, tc))
+}
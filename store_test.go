@@ -0,0 +1,120 @@
+package collections
+
+import (
+	"context"
+	"testing"
+
+	corestore "cosmossdk.io/core/store"
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+// kvStoreService implements the core KVStoreService
+// on top of an sdk StoreKey, used for testing.
+type kvStoreService struct {
+	sk storetypes.StoreKey
+}
+
+func (k kvStoreService) OpenKVStore(ctx context.Context) corestore.KVStore {
+	return sdkKVStore{sdk.UnwrapSDKContext(ctx).KVStore(k.sk)}
+}
+
+// sdkKVStore adapts an sdk KVStore into a core KVStore.
+type sdkKVStore struct {
+	s storetypes.KVStore
+}
+
+func (s sdkKVStore) Get(key []byte) ([]byte, error) { return s.s.Get(key), nil }
+func (s sdkKVStore) Has(key []byte) (bool, error)   { return s.s.Has(key), nil }
+func (s sdkKVStore) Set(key, value []byte) error    { s.s.Set(key, value); return nil }
+func (s sdkKVStore) Delete(key []byte) error        { s.s.Delete(key); return nil }
+func (s sdkKVStore) Iterator(start, end []byte) (corestore.Iterator, error) {
+	return s.s.Iterator(start, end), nil
+}
+
+func (s sdkKVStore) ReverseIterator(start, end []byte) (corestore.Iterator, error) {
+	return s.s.ReverseIterator(start, end), nil
+}
+
+// memoryStoreService implements the core Transient and Memory
+// store services on top of a KVStoreService, used for testing.
+type memoryStoreService struct {
+	corestore.KVStoreService
+}
+
+func (m memoryStoreService) OpenTransientStore(ctx context.Context) corestore.KVStore {
+	return m.OpenKVStore(ctx)
+}
+
+func (m memoryStoreService) OpenMemoryStore(ctx context.Context) corestore.KVStore {
+	return m.OpenKVStore(ctx)
+}
+
+func TestStoreServiceCollections(t *testing.T) {
+	sk, sdkCtx, _ := deps()
+	ss := kvStoreService{sk}
+	// modules built on the core API only receive a context.Context
+	ctx := context.WithValue(context.Background(), sdk.SdkContextKey, sdkCtx)
+
+	m := NewMapWithStore[string, string](KVStoreServiceAccessor(ss), 0, StringKeyEncoder, stringValue{})
+	m.Insert(ctx, "k", "v")
+	got, err := m.Get(ctx, "k")
+	require.NoError(t, err)
+	require.Equal(t, "v", got)
+
+	// the state is shared with the StoreKey based collections.
+	got, err = NewMap[string, string](sk, 0, StringKeyEncoder, stringValue{}).Get(sdkCtx, "k")
+	require.NoError(t, err)
+	require.Equal(t, "v", got)
+
+	ks := NewKeySetWithStore[string](KVStoreServiceAccessor(ss), 1, StringKeyEncoder)
+	ks.Insert(ctx, "k")
+	require.True(t, ks.Has(ctx, "k"))
+
+	item := NewItemWithStore[uint64](TransientStoreServiceAccessor(memoryStoreService{ss}), 2, Uint64ValueEncoder)
+	item.Set(ctx, 10)
+	require.Equal(t, uint64(10), item.GetOr(ctx, 0))
+
+	seq := NewSequenceWithStore(MemoryStoreServiceAccessor(memoryStoreService{ss}), 3)
+	require.Equal(t, DefaultSequenceStart, seq.Next(ctx))
+	require.Equal(t, DefaultSequenceStart+1, seq.Peek(ctx))
+}
+
+func TestStoreServiceIndexedMap(t *testing.T) {
+	sk, sdkCtx, _ := deps()
+	sa := KVStoreServiceAccessor(kvStoreService{sk})
+	ctx := context.WithValue(context.Background(), sdk.SdkContextKey, sdkCtx)
+
+	m := NewIndexedMapWithStore[uint64, person, indexes](
+		sa, 0,
+		Uint64KeyEncoder, jsonValue[person]{},
+		indexes{
+			City: NewMultiIndexWithStore[string, uint64, person](sa, 1,
+				StringKeyEncoder, Uint64KeyEncoder,
+				func(v person) string {
+					return v.City
+				}),
+		},
+	)
+
+	m.Insert(ctx, 0, person{ID: 0, City: "milan"})
+	m.Insert(ctx, 1, person{ID: 1, City: "milan"})
+	require.Equal(t, []uint64{0, 1}, m.Indexes.City.ExactMatch(ctx, "milan").PrimaryKeys())
+}
+
+func TestStoreServiceCacheWrap(t *testing.T) {
+	sk, ctx, _ := deps()
+	ss := kvStoreService{sk}
+	m := NewMapWithStore[string, string](KVStoreServiceAccessor(ss), 0, StringKeyEncoder, stringValue{})
+
+	kv := KVStoreServiceAccessor(ss)(ctx)
+	require.Equal(t, storetypes.StoreTypeDB, kv.GetStoreType())
+	require.Equal(t, storetypes.StoreTypeMemory, MemoryStoreServiceAccessor(memoryStoreService{ss})(ctx).GetStoreType())
+
+	cache := kv.CacheWrap().(storetypes.CacheKVStore)
+	cache.Set(append(Namespace(0).Prefix(), StringKeyEncoder.Encode("k")...), stringValue{}.Encode("v"))
+	require.False(t, m.Has(ctx, "k"))
+	cache.Write()
+	require.Equal(t, "v", m.GetOr(ctx, "k", ""))
+}
@@ -1,6 +1,7 @@
 package collections
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -15,6 +16,16 @@ func assertBijective[T any](t *testing.T, encoder KeyEncoder[T], key T) {
 	require.Equal(t, key, decodedKey, "encoding and decoding produces different keys")
 }
 
+// assertOrdered asserts that the provided keys, which are expected
+// to be sorted, produce encoded keys which are sorted too.
+func assertOrdered[T any](t *testing.T, encoder KeyEncoder[T], sortedKeys []T) {
+	for i := 1; i < len(sortedKeys); i++ {
+		prev, curr := encoder.Encode(sortedKeys[i-1]), encoder.Encode(sortedKeys[i])
+		require.Negative(t, bytes.Compare(prev, curr), "ordering mismatch between %s and %s",
+			encoder.Stringify(sortedKeys[i-1]), encoder.Stringify(sortedKeys[i]))
+	}
+}
+
 func assertValueBijective[T any](t *testing.T, encoder ValueEncoder[T], value T) {
 	encodedValue := encoder.Encode(value)
 	decodedValue := encoder.Decode(encodedValue)
@@ -16,6 +16,9 @@ var (
 	DecValueEncoder        ValueEncoder[math.LegacyDec] = decValueEncoder{}
 	IntValueEncoder        ValueEncoder[math.Int]       = intValueEncoder{}
 	Uint64ValueEncoder     ValueEncoder[uint64]         = uint64Value{}
+	Uint32ValueEncoder     ValueEncoder[uint32]         = uint32Value{}
+	Uint16ValueEncoder     ValueEncoder[uint16]         = uint16Value{}
+	Uint8ValueEncoder      ValueEncoder[uint8]          = uint8Value{}
 )
 
 // ProtoValueEncoder returns a protobuf value encoder given the codec.BinaryCodec.
@@ -85,16 +88,31 @@ func (a accAddressValueEncoder) Stringify(value sdk.AccAddress) string { return
 func (a accAddressValueEncoder) Name() string                          { return "sdk.AccAddress" }
 
 // IntValueEncoder ValueEncoder[sdk.Int]
+// Non-negative values are encoded using IntKeyEncoder, while negative
+// values are encoded using SignedIntKeyEncoder. The two encodings have
+// different lengths, which makes it possible to tell them apart when
+// decoding and keeps the state of non-negative values backwards compatible.
 
 type intValueEncoder struct{}
 
 func (intValueEncoder) Encode(value math.Int) []byte {
+	if !value.IsNil() && value.IsNegative() {
+		return SignedIntKeyEncoder.Encode(value)
+	}
 	return IntKeyEncoder.Encode(value)
 }
 
 func (intValueEncoder) Decode(b []byte) math.Int {
-	_, got := IntKeyEncoder.Decode(b)
-	return got
+	switch len(b) {
+	case maxIntKeyLen:
+		_, got := IntKeyEncoder.Decode(b)
+		return got
+	case signedIntKeyLen:
+		_, got := SignedIntKeyEncoder.Decode(b)
+		return got
+	default:
+		panic(fmt.Errorf("invalid math.Int value bytes: %s", HumanizeBytes(b)))
+	}
 }
 
 func (intValueEncoder) Stringify(value math.Int) string {
@@ -128,6 +146,8 @@ func (intKeyEncoder) Encode(key math.Int) []byte {
 	return padded
 }
 
+func (intKeyEncoder) decodeCopies() bool { return true }
+
 func (intKeyEncoder) Decode(b []byte) (int, math.Int) {
 	if len(b) != maxIntKeyLen {
 		panic("invalid key length")
@@ -137,3 +157,89 @@ func (intKeyEncoder) Decode(b []byte) (int, math.Int) {
 }
 
 func (intKeyEncoder) Stringify(key math.Int) string { return key.String() }
+func (intKeyEncoder) Size(math.Int) int             { return maxIntKeyLen }
+
+func (intKeyEncoder) PutKey(buf []byte, key math.Int) int {
+	if key.IsNil() {
+		panic("cannot encode invalid math.Int")
+	}
+	if key.IsNegative() {
+		panic("cannot encode negative math.Int")
+	}
+	key.BigIntMut().FillBytes(buf[:maxIntKeyLen])
+	return maxIntKeyLen
+}
+
+// SignedIntKeyEncoder can be used to encode math.Int keys which can be negative.
+// The key is composed of a sign byte followed by the big endian,
+// fixed length, absolute value of the math.Int. The absolute value
+// bytes of negative numbers are complemented, so that the byte
+// ordering of the keys matches their numeric ordering.
+var SignedIntKeyEncoder KeyEncoder[math.Int] = signedIntKeyEncoder{}
+
+type signedIntKeyEncoder struct{}
+
+const (
+	signedIntKeyLen = maxIntKeyLen + 1
+
+	intKeyNegative byte = 0
+	intKeyPositive byte = 1
+)
+
+func (signedIntKeyEncoder) Encode(key math.Int) []byte {
+	if key.IsNil() {
+		panic("cannot encode invalid math.Int")
+	}
+	be := new(big.Int).Abs(key.BigInt()).Bytes()
+	b := make([]byte, signedIntKeyLen)
+	copy(b[signedIntKeyLen-len(be):], be)
+	if !key.IsNegative() {
+		b[0] = intKeyPositive
+		return b
+	}
+	b[0] = intKeyNegative
+	for i := 1; i < signedIntKeyLen; i++ {
+		b[i] = ^b[i]
+	}
+	return b
+}
+
+func (signedIntKeyEncoder) decodeCopies() bool { return true }
+
+func (signedIntKeyEncoder) Decode(b []byte) (int, math.Int) {
+	if len(b) < signedIntKeyLen {
+		panic(fmt.Errorf("invalid signed math.Int key length: %s", HumanizeBytes(b)))
+	}
+	abs := make([]byte, maxIntKeyLen)
+	copy(abs, b[1:signedIntKeyLen])
+	switch b[0] {
+	case intKeyPositive:
+		return signedIntKeyLen, math.NewIntFromBigInt(new(big.Int).SetBytes(abs))
+	case intKeyNegative:
+		for i := range abs {
+			abs[i] = ^abs[i]
+		}
+		i := new(big.Int).SetBytes(abs)
+		return signedIntKeyLen, math.NewIntFromBigInt(i.Neg(i))
+	default:
+		panic(fmt.Errorf("invalid signed math.Int key sign byte: %d %s", b[0], HumanizeBytes(b)))
+	}
+}
+
+func (signedIntKeyEncoder) Stringify(key math.Int) string { return key.String() }
+func (signedIntKeyEncoder) Size(math.Int) int             { return signedIntKeyLen }
+
+func (signedIntKeyEncoder) PutKey(buf []byte, key math.Int) int {
+	if key.IsNil() {
+		panic("cannot encode invalid math.Int")
+	}
+	// FillBytes writes the absolute value.
+	key.BigIntMut().FillBytes(buf[1:signedIntKeyLen])
+	if !key.IsNegative() {
+		buf[0] = intKeyPositive
+		return signedIntKeyLen
+	}
+	buf[0] = intKeyNegative
+	complementBytes(buf[1:signedIntKeyLen])
+	return signedIntKeyLen
+}
@@ -52,6 +52,19 @@ func (s *SuiteValueEncoder) TestUint64ValueEncoder() {
 	})
 }
 
+func (s *SuiteValueEncoder) TestFixedWidthUintValueEncoders() {
+	s.Run("bijectivity", func() {
+		assertValueBijective(s.T(), Uint32ValueEncoder, 1000)
+		assertValueBijective(s.T(), Uint16ValueEncoder, 1000)
+		assertValueBijective(s.T(), Uint8ValueEncoder, 100)
+	})
+	s.Run("panics on invalid length", func() {
+		s.Panics(func() { Uint32ValueEncoder.Decode([]byte{0, 0, 0, 0, 0}) })
+		s.Panics(func() { Uint16ValueEncoder.Decode([]byte{0}) })
+		s.Panics(func() { Uint8ValueEncoder.Decode([]byte{}) })
+	})
+}
+
 func (s *SuiteValueEncoder) TestIntEncoder() {
 	// we test our assumptions around int are correct.
 	outOfBounds := new(big.Int).Lsh(big.NewInt(1), 256)       // 2^256
@@ -99,4 +112,44 @@ func (s *SuiteValueEncoder) TestIntEncoder() {
 	s.Panics(func() {
 		IntValueEncoder.Encode(math.Int{})
 	})
+
+	// negative values are supported by the value encoder
+	assertValueBijective(s.T(), IntValueEncoder, math.NewInt(-50_000))
+	assertValueBijective(s.T(), IntValueEncoder, math.NewIntFromBigInt(new(big.Int).Neg(maxBigInt)))
+	// non-negative values keep the legacy encoding
+	s.Equal(IntKeyEncoder.Encode(value), valueBytes)
+	s.Panics(func() {
+		IntValueEncoder.Decode([]byte{0x1})
+	})
+}
+
+func (s *SuiteValueEncoder) TestSignedIntKeyEncoder() {
+	maxBigInt := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)) // 2^256 - 1
+	maxInt := math.NewIntFromBigInt(maxBigInt)
+	minInt := math.NewIntFromBigInt(new(big.Int).Neg(maxBigInt))
+
+	s.Run("bijectivity", func() {
+		for _, i := range []math.Int{minInt, math.NewInt(-1), math.ZeroInt(), math.NewInt(1), maxInt} {
+			assertBijective(s.T(), SignedIntKeyEncoder, i)
+		}
+	})
+
+	s.Run("proper ordering", func() {
+		assertOrdered(s.T(), SignedIntKeyEncoder, []math.Int{
+			minInt, math.NewInt(-100_000), math.NewInt(-256), math.NewInt(-255), math.NewInt(-1),
+			math.ZeroInt(), math.NewInt(1), math.NewInt(255), math.NewInt(256), math.NewInt(100_000), maxInt,
+		})
+	})
+
+	s.Run("pair prefix", func() {
+		assertBijective(s.T(), PairKeyEncoder(SignedIntKeyEncoder, StringKeyEncoder), Join(math.NewInt(-7), "pnl"))
+	})
+
+	s.Run("panics", func() {
+		s.Panics(func() { SignedIntKeyEncoder.Encode(math.Int{}) })
+		s.Panics(func() { SignedIntKeyEncoder.Decode([]byte{intKeyPositive}) })
+		invalidSign := make([]byte, signedIntKeyLen)
+		invalidSign[0] = 2
+		s.Panics(func() { SignedIntKeyEncoder.Decode(invalidSign) })
+	})
 }